@@ -0,0 +1,32 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+// Antithetic generates antithetic pairs of uniform variates for variance reduction
+// in Monte Carlo simulations: odd calls to [Antithetic.Float64] return a fresh value U,
+// and even calls return its complement 1-U. Consecutive values are therefore not independent.
+type Antithetic struct {
+	r    *Rand
+	u    float64
+	pair bool
+}
+
+// NewAntithetic returns an Antithetic that draws fresh values from r.
+func NewAntithetic(r *Rand) *Antithetic {
+	return &Antithetic{r: r}
+}
+
+// Float64 returns either a fresh uniformly distributed pseudo-random number in the half-open interval [0.0, 1.0),
+// or the complement of the previously returned value, in the half-open interval (0.0, 1.0].
+func (a *Antithetic) Float64() float64 {
+	if a.pair {
+		a.pair = false
+		return 1 - a.u
+	}
+	a.u, a.pair = a.r.Float64(), true
+	return a.u
+}
@@ -0,0 +1,34 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+func TestAntithetic_Float64(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		k := rapid.IntRange(1, small).Draw(t, "k").(int)
+		a := rand.NewAntithetic(rand.New(s))
+		r := rand.New(s)
+		sum := 0.0
+		for i := 0; i < k; i++ {
+			u, c := a.Float64(), a.Float64()
+			if f := r.Float64(); u != f || c != 1-f {
+				t.Fatalf("got pair (%v, %v) instead of (%v, %v)", u, c, f, 1-f)
+			}
+			sum += u + c
+		}
+		if m := sum / float64(2*k); math.Abs(m-0.5) > 1e-12 {
+			t.Fatalf("got mean %v instead of 0.5", m)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "math/bits"
+
+// A Batch generates uniformly distributed pseudo-random numbers in [0, n) for a fixed n,
+// extracting as many values as possible from each 64-bit output of the underlying generator.
+// For small n, this is faster than calling [Rand.Uint64n] for every value.
+type Batch struct {
+	r   *Rand
+	n   uint64
+	m   uint64 // n^k mod 2^64, where k is the number of values per 64-bit output
+	t   uint64 // rejection threshold, 2^64 mod n^k
+	k   int
+	lo  uint64
+	rem int
+}
+
+// NewBatch returns a Batch that generates values in [0, n) using r. It panics if n == 0.
+func NewBatch(r *Rand, n uint64) *Batch {
+	if n == 0 {
+		panic("invalid argument to NewBatch")
+	}
+	b := &Batch{r: r, n: n, m: n, k: 1}
+	for b.k < 64 {
+		hi, lo := bits.Mul64(b.m, n)
+		if hi != 0 {
+			if hi == 1 && lo == 0 {
+				// n^(k+1) == 2^64, every output is used without rejection
+				b.m = 0
+				b.k++
+			}
+			break
+		}
+		b.m = lo
+		b.k++
+	}
+	if b.m != 0 {
+		b.t = -b.m % b.m
+	}
+	return b
+}
+
+// Next returns, as an uint64, a uniformly distributed pseudo-random number in [0, n).
+func (b *Batch) Next() (v uint64) { // named return value lowers inlining cost
+	if b.rem == 0 {
+		b.fill()
+	}
+	b.rem--
+	v, b.lo = bits.Mul64(b.lo, b.n)
+	return
+}
+
+func (b *Batch) fill() {
+	// "Batched Ranged Random Integer Generation" by Nevin Brackett-Rozinsky and Daniel Lemire, https://arxiv.org/abs/2408.06213:
+	// k values are the successive high words of x*n, lo*n, ..., and the batch is rejected when
+	// the final low word, x*n^k mod 2^64, is below 2^64 mod n^k.
+	x := b.r.next64()
+	for x*b.m < b.t {
+		x = b.r.next64()
+	}
+	b.lo, b.rem = x, b.k
+}
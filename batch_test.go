@@ -0,0 +1,76 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"fmt"
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+var batchSizes = []uint64{6, 52, 256}
+
+func BenchmarkBatch_Next(b *testing.B) {
+	for _, n := range batchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			bt := rand.NewBatch(rand.New(1), n)
+			var s uint64
+			for i := 0; i < b.N; i++ {
+				s = bt.Next()
+			}
+			sinkUint64 = s
+		})
+	}
+}
+
+func BenchmarkBatch_Uint64n(b *testing.B) {
+	for _, n := range batchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			r := rand.New(1)
+			var s uint64
+			for i := 0; i < b.N; i++ {
+				s = r.Uint64n(n)
+			}
+			sinkUint64 = s
+		})
+	}
+}
+
+func TestBatch_Next(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		n := rapid.Uint64Range(1, math.MaxUint64).Draw(t, "n").(uint64)
+		b := rand.NewBatch(rand.New(s), n)
+		for i := 0; i < 100; i++ {
+			if v := b.Next(); v >= n {
+				t.Fatalf("got %v outside of [0, %v)", v, n)
+			}
+		}
+	})
+}
+
+func TestBatch_Uniform(t *testing.T) {
+	const N = 1000000
+	for _, n := range append([]uint64{1, 2, 3, 7, 1000}, batchSizes...) {
+		b := rand.NewBatch(rand.New(1), n)
+		counts := make([]float64, n)
+		for i := 0; i < N; i++ {
+			counts[b.Next()]++
+		}
+		// chi-squared test, with threshold far above the expected value of n-1
+		want := float64(N) / float64(n)
+		chi2 := 0.0
+		for _, c := range counts {
+			chi2 += (c - want) * (c - want) / want
+		}
+		if df := float64(n - 1); chi2 > df+6*math.Sqrt(2*df)+10 {
+			t.Errorf("n %v: chi-squared statistic %v is too large for %v degrees of freedom", n, chi2, df)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+// A ByteDist samples bytes with a non-uniform distribution, using Vose's alias method.
+type ByteDist struct {
+	prob  [256]float64
+	alias [256]byte
+}
+
+// NewByteDist returns a distribution where byte b has probability proportional to freqs[b].
+// It returns an error if the frequencies are negative, infinite or all zero.
+func NewByteDist(freqs [256]float64) (*ByteDist, error) {
+	cum, err := cumulativeWeights(freqs[:])
+	if err != nil {
+		return nil, err
+	}
+	d := &ByteDist{}
+	d.init(&freqs, cum[len(cum)-1])
+	return d, nil
+}
+
+func (d *ByteDist) init(freqs *[256]float64, sum float64) {
+	var small, large []byte
+	for i, f := range freqs {
+		d.prob[i] = f * 256 / sum
+		if d.prob[i] < 1 {
+			small = append(small, byte(i))
+		} else {
+			large = append(large, byte(i))
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		d.alias[s] = l
+		d.prob[l] -= 1 - d.prob[s]
+		if d.prob[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// leftovers are due to rounding errors and have probability of 1
+	for _, i := range large {
+		d.prob[i] = 1
+	}
+	for _, i := range small {
+		d.prob[i] = 1
+	}
+}
+
+// Byte returns a byte drawn from the distribution.
+func (d *ByteDist) Byte(r *Rand) byte {
+	v := r.next64()
+	i := byte(v)
+	if float64(v>>11)*f53Mul < d.prob[i] {
+		return i
+	}
+	return d.alias[i]
+}
+
+// Fill fills p with bytes drawn from the distribution.
+func (d *ByteDist) Fill(r *Rand, p []byte) {
+	for i := range p {
+		p[i] = d.Byte(r)
+	}
+}
+
+// SkewedBytes fills p with pseudo-random bytes, where byte b appears with probability
+// proportional to freqs[b]. To fill many slices with the same frequencies, use [NewByteDist].
+// It panics if the frequencies are negative, infinite or all zero.
+func (r *Rand) SkewedBytes(p []byte, freqs [256]float64) {
+	cum, err := cumulativeWeights(freqs[:])
+	if err != nil {
+		panic("invalid argument to SkewedBytes")
+	}
+	var d ByteDist
+	d.init(&freqs, cum[len(cum)-1])
+	d.Fill(r, p)
+}
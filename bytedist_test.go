@@ -0,0 +1,69 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"testing"
+)
+
+func TestRand_SkewedBytes(t *testing.T) {
+	const N = 1 << 20
+	var freqs [256]float64
+	for i := range freqs {
+		if i%3 != 0 {
+			freqs[i] = float64(i % 17)
+		}
+	}
+	freqs['a'] = 500
+	sum := 0.0
+	for _, f := range freqs {
+		sum += f
+	}
+	r := rand.New(1)
+	p := make([]byte, N)
+	r.SkewedBytes(p, freqs)
+	var counts [256]int
+	for _, b := range p {
+		counts[b]++
+	}
+	for i, c := range counts {
+		want := N * freqs[i] / sum
+		if freqs[i] == 0 && c != 0 {
+			t.Errorf("got %v bytes %#x with zero frequency", c, i)
+		}
+		if math.Abs(float64(c)-want) > 5*math.Sqrt(want)+1 {
+			t.Errorf("got %v bytes %#x instead of ~%v", c, i, want)
+		}
+	}
+}
+
+func TestNewByteDist(t *testing.T) {
+	var freqs [256]float64
+	if _, err := rand.NewByteDist(freqs); err == nil {
+		t.Errorf("got no error for all-zero frequencies")
+	}
+	freqs[1] = -1
+	freqs[2] = 2
+	if _, err := rand.NewByteDist(freqs); err == nil {
+		t.Errorf("got no error for negative frequency")
+	}
+	freqs[1] = 0
+	d, err := rand.NewByteDist(freqs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r := rand.New(1)
+	p := make([]byte, small)
+	d.Fill(r, p)
+	for _, b := range p {
+		if b != 2 {
+			t.Fatalf("got byte %v with zero frequency", b)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "context"
+
+// Uint64Chan returns an unbuffered channel of pseudo-random 64-bit values, produced by a new goroutine
+// that owns r until ctx is cancelled: r must not be used by other goroutines in the meantime.
+// Values are drawn from r one at a time as they are received, so the sequence received from the channel
+// is the same as the sequence of r.Uint64 calls. Once ctx is cancelled, the goroutine exits
+// and closes the channel; at most one value drawn from r is discarded.
+func (r *Rand) Uint64Chan(ctx context.Context) <-chan uint64 {
+	ch := make(chan uint64)
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case ch <- r.Uint64():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"context"
+	"pgregory.net/rand"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestRand_Uint64Chan(t *testing.T) {
+	r1 := rand.New(1)
+	r2 := rand.New(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := r1.Uint64Chan(ctx)
+	for i := 0; i < small; i++ {
+		if u1, u2 := <-ch, r2.Uint64(); u1 != u2 {
+			t.Fatalf("got %v from channel instead of %v", u1, u2)
+		}
+	}
+	cancel()
+	for range ch {
+	}
+}
+
+func TestRand_Uint64Chan_Cancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	for i := 0; i < 10; i++ {
+		<-rand.New(uint64(i)).Uint64Chan(ctx)
+	}
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("got %v goroutines after cancellation instead of %v", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "math"
+
+// A ChoiceTree is a tree of weighted choices: leaves carry values, and every internal node
+// chooses one of its children with probability proportional to the child's weight.
+// Trees are built with [NewChoiceLeaf], [NewChoiceNode] and [ChoiceTree.Add].
+type ChoiceTree struct {
+	value    interface{}
+	leaf     bool
+	cum      []float64
+	children []*ChoiceTree
+}
+
+// NewChoiceLeaf returns a leaf carrying value.
+func NewChoiceLeaf(value interface{}) *ChoiceTree {
+	return &ChoiceTree{value: value, leaf: true}
+}
+
+// NewChoiceNode returns an internal node without children.
+func NewChoiceNode() *ChoiceTree {
+	return &ChoiceTree{}
+}
+
+// Add adds child to the internal node t with the given weight and returns t, so that calls can be chained.
+// It panics if t is a leaf, child is nil, or weight is negative or not finite.
+func (t *ChoiceTree) Add(weight float64, child *ChoiceTree) *ChoiceTree {
+	if t.leaf || child == nil || !(weight >= 0) || math.IsInf(weight, 1) {
+		panic("invalid argument to Add")
+	}
+	sum := 0.0
+	if len(t.cum) > 0 {
+		sum = t.cum[len(t.cum)-1]
+	}
+	t.cum = append(t.cum, sum+weight)
+	t.children = append(t.children, child)
+	return t
+}
+
+// Sample descends from t to a leaf, choosing a child by weight at every internal node,
+// and returns the value of the leaf. The probability of a leaf is the product of the normalized
+// weights along its path. It panics if it reaches an internal node whose children all have zero weight.
+func (t *ChoiceTree) Sample(r *Rand) interface{} {
+	for !t.leaf {
+		if len(t.cum) == 0 || !(t.cum[len(t.cum)-1] > 0) {
+			panic("ChoiceTree node has no children with positive weight")
+		}
+		t = t.children[r.searchCumulative(t.cum)]
+	}
+	return t.value
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"testing"
+)
+
+func TestChoiceTree(t *testing.T) {
+	const N = 100000
+	tree := rand.NewChoiceNode().
+		Add(1, rand.NewChoiceLeaf("a")).
+		Add(3, rand.NewChoiceNode().
+			Add(1, rand.NewChoiceLeaf("b")).
+			Add(0, rand.NewChoiceLeaf("never")).
+			Add(2, rand.NewChoiceNode().
+				Add(1, rand.NewChoiceLeaf("c")).
+				Add(1, rand.NewChoiceLeaf("d"))))
+	want := map[string]float64{
+		"a": 1.0 / 4,
+		"b": 3.0 / 4 * 1.0 / 3,
+		"c": 3.0 / 4 * 2.0 / 3 * 1.0 / 2,
+		"d": 3.0 / 4 * 2.0 / 3 * 1.0 / 2,
+	}
+	r := rand.New(1)
+	counts := map[string]int{}
+	for i := 0; i < N; i++ {
+		counts[tree.Sample(r).(string)]++
+	}
+	if counts["never"] != 0 {
+		t.Errorf("zero-weight leaf chosen %v times", counts["never"])
+	}
+	for v, p := range want {
+		if c := float64(counts[v]); math.Abs(c-N*p) > 5*math.Sqrt(N*p*(1-p)) {
+			t.Errorf("leaf %v chosen %v times instead of ~%v", v, c, N*p)
+		}
+	}
+}
+
+func TestChoiceTree_Leaf(t *testing.T) {
+	if v := rand.NewChoiceLeaf(42).Sample(rand.New(1)); v != 42 {
+		t.Errorf("got %v from a single leaf instead of 42", v)
+	}
+}
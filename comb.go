@@ -0,0 +1,339 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+)
+
+// RandomBST returns, as a slice of n ints, a pseudo-random permutation of the integers in the
+// half-open interval [0, n). Inserting the keys into an unbalanced binary search tree in the
+// returned order produces a random BST shape; note that the shapes are not uniformly
+// distributed, since balanced shapes are more likely. See [Rand.RandomBinaryTree] for
+// uniformly distributed shapes.
+func (r *Rand) RandomBST(n int) []int {
+	return r.Perm(n)
+}
+
+// RandomBinaryTree returns the shape of a binary tree with n nodes, chosen uniformly
+// among all such shapes. Nodes are numbered from 0 to n-1 in preorder, so the root is 0 when n > 0;
+// left[i] and right[i] are the children of node i, or -1 if there is no such child.
+// It panics if n < 0.
+func (r *Rand) RandomBinaryTree(n int) (left []int, right []int) {
+	if n < 0 {
+		panic("invalid argument to RandomBinaryTree")
+	}
+	// Rémy's algorithm grows a uniformly random full binary tree with n internal nodes
+	// and n+1 leaves; removing the leaves results in a uniformly random binary tree.
+	// Nodes 0 and 2i-1 are leaves, and 2i are internal, for i in [1, n].
+	m := 2*n + 1
+	parent := make([]int, m)
+	child := make([][2]int, m)
+	parent[0] = -1
+	root := 0
+	for i := 1; i <= n; i++ {
+		x := int(r.Uint64n(uint64(2*i - 1)))
+		in, leaf := 2*i, 2*i-1
+		p := parent[x]
+		if p < 0 {
+			root = in
+		} else if child[p][0] == x {
+			child[p][0] = in
+		} else {
+			child[p][1] = in
+		}
+		parent[in] = p
+		side := r.Uint64n(2)
+		child[in][side], child[in][1-side] = x, leaf
+		parent[x], parent[leaf] = in, in
+	}
+	left = make([]int, n)
+	right = make([]int, n)
+	if n == 0 {
+		return
+	}
+	label := make([]int, m)
+	next := 0
+	stack := []int{root}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		label[v] = next
+		next++
+		for s := 1; s >= 0; s-- {
+			if c := child[v][s]; c%2 == 0 && c != 0 {
+				stack = append(stack, c)
+			}
+		}
+	}
+	for v := 2; v < m; v += 2 {
+		l, rt := -1, -1
+		if c := child[v][0]; c%2 == 0 && c != 0 {
+			l = label[c]
+		}
+		if c := child[v][1]; c%2 == 0 && c != 0 {
+			rt = label[c]
+		}
+		left[label[v]], right[label[v]] = l, rt
+	}
+	return
+}
+
+// PermWithInversions returns, as a slice of n ints, a pseudo-random permutation of the integers
+// in the half-open interval [0, n) with exactly k inversions (pairs of elements that are out of order).
+// Every such permutation can be returned, but they are not equally likely.
+// It panics if n < 0, k < 0 or k > n*(n-1)/2.
+func (r *Rand) PermWithInversions(n, k int) []int {
+	if n < 0 || k < 0 || uint64(k) > uint64(n)*uint64(n-1)/2 {
+		panic("invalid argument to PermWithInversions")
+	}
+	// inversion vector: value i has c[i] <= i smaller values after it
+	c := make([]int, n)
+	for i := n - 1; i >= 0; i-- {
+		lo, hi := k-i*(i-1)/2, k // values below i can have at most i*(i-1)/2 inversions
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > i {
+			hi = i
+		}
+		c[i] = lo + int(r.Uint64n(uint64(hi-lo)+1))
+		k -= c[i]
+	}
+	// from the largest value down, value i takes the (i - c[i])-th of the free positions
+	p := make([]int, n)
+	t := newFenwick(n)
+	for i := n - 1; i >= 0; i-- {
+		j := t.find(i - c[i])
+		t.add(j, -1)
+		p[j] = i
+	}
+	return p
+}
+
+// RandomTree returns a labeled tree on n nodes, chosen uniformly among all n^(n-2) such trees,
+// as a parent array rooted at node 0: parent[0] is -1, and parent[i] is the parent of node i otherwise.
+// The tree is decoded from a pseudo-random Prüfer sequence. It panics if n < 0.
+func (r *Rand) RandomTree(n int) []int {
+	if n < 0 {
+		panic("invalid argument to RandomTree")
+	}
+	adj := make([][]int, n)
+	if n >= 2 {
+		prufer := make([]int, n-2)
+		degree := make([]int, n)
+		for i := range degree {
+			degree[i] = 1
+		}
+		for i := range prufer {
+			prufer[i] = int(r.Uint64n(uint64(n)))
+			degree[prufer[i]]++
+		}
+		// linear-time decoding: leaf is the smallest node of degree 1
+		leaf := 0
+		for degree[leaf] != 1 {
+			leaf++
+		}
+		next := leaf
+		for _, v := range prufer {
+			adj[leaf] = append(adj[leaf], v)
+			adj[v] = append(adj[v], leaf)
+			degree[v]--
+			if degree[v] == 1 && v < next {
+				leaf = v
+				continue
+			}
+			next++
+			for degree[next] != 1 {
+				next++
+			}
+			leaf = next
+		}
+		// the two remaining nodes of degree 1 are leaf and n-1
+		adj[leaf] = append(adj[leaf], n-1)
+		adj[n-1] = append(adj[n-1], leaf)
+	}
+	parent := make([]int, n)
+	if n == 0 {
+		return parent
+	}
+	parent[0] = -1
+	stack := []int{0}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, v := range adj[u] {
+			if v != parent[u] {
+				parent[v] = u
+				stack = append(stack, v)
+			}
+		}
+	}
+	return parent
+}
+
+// Involution returns, as a slice of n ints, a permutation of the integers in the half-open interval
+// [0, n) that is its own inverse, chosen uniformly among all such permutations. An involution
+// consists of fixed points and transpositions only. It panics if n < 0.
+func (r *Rand) Involution(n int) []int {
+	if n < 0 {
+		panic("invalid argument to Involution")
+	}
+	// q[m] = I(m)/I(m-1), where I(m) = I(m-1) + (m-1)*I(m-2) is the number of involutions of m elements
+	q := make([]float64, n+1)
+	if n > 0 {
+		q[1] = 1
+	}
+	for m := 2; m <= n; m++ {
+		q[m] = 1 + float64(m-1)/q[m-1]
+	}
+	p := make([]int, n)
+	free := make([]int, n)
+	for i := range free {
+		free[i] = i
+	}
+	for m := n; m > 0; m = len(free) {
+		// the last free element is a fixed point with probability I(m-1)/I(m)
+		x := free[m-1]
+		free = free[:m-1]
+		if r.Float64()*q[m] < 1 {
+			p[x] = x
+			continue
+		}
+		j := int(r.Uint64n(uint64(m - 1)))
+		y := free[j]
+		free[j] = free[m-2]
+		free = free[:m-2]
+		p[x], p[y] = y, x
+	}
+	return p
+}
+
+// fenwick is a binary indexed tree for counting free positions.
+type fenwick []int
+
+func newFenwick(n int) fenwick {
+	t := make(fenwick, n+1)
+	for i := 1; i <= n; i++ {
+		t[i]++
+		if j := i + i&-i; j <= n {
+			t[j] += t[i]
+		}
+	}
+	return t
+}
+
+func (t fenwick) add(i int, d int) {
+	for i++; i < len(t); i += i & -i {
+		t[i] += d
+	}
+}
+
+// find returns the smallest position i such that the count of positions [0, i] is k+1.
+func (t fenwick) find(k int) int {
+	pos := 0
+	step := 1
+	for step*2 < len(t) {
+		step *= 2
+	}
+	for ; step > 0; step /= 2 {
+		if pos+step < len(t) && t[pos+step] <= k {
+			pos += step
+			k -= t[pos]
+		}
+	}
+	return pos
+}
+
+// IntPartition returns a partition of n, chosen uniformly among all partitions of n: a slice of
+// positive ints in non-increasing order that sum to n. It uses the algorithm of Nijenhuis and Wilf,
+// with partition numbers computed exactly and then rounded to float64 logarithms, so the uniformity
+// is approximate for large n. It panics if n < 0.
+func (r *Rand) IntPartition(n int) []int {
+	if n < 0 {
+		panic("invalid argument to IntPartition")
+	}
+	lp := logPartitionNumbers(n)
+	var parts []int
+	for m := n; m > 0; {
+		// choose (d, j) with probability d*p(m - j*d) / (m*p(m)), and add j parts equal to d;
+		// the ratios of partition numbers are computed from their logarithms, since p(m) overflows
+		// float64 for m above about 76000
+		z := r.Float64() * float64(m)
+		d, j := 1, m // fallback in case of rounding errors: m parts equal to 1
+	search:
+		for dd := 1; dd <= m; dd++ {
+			for jj := 1; jj*dd <= m; jj++ {
+				z -= float64(dd) * math.Exp(lp[m-jj*dd]-lp[m])
+				if z < 0 {
+					d, j = dd, jj
+					break search
+				}
+			}
+		}
+		for i := 0; i < j; i++ {
+			parts = append(parts, d)
+		}
+		m -= j * d
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(parts)))
+	return parts
+}
+
+// partitionNumbers caches the exact numbers of partitions and their logarithms, since the
+// pentagonal number recurrence needs all the previous numbers.
+var partitionNumbers struct {
+	mu sync.Mutex
+	p  []*big.Int
+	lp []float64
+}
+
+// logPartitionNumbers returns the natural logarithms of the numbers of partitions of 0 to n.
+// The numbers are computed exactly with Euler's pentagonal number theorem, since its alternating
+// sums lose all precision in floating point for n above about 1000.
+func logPartitionNumbers(n int) []float64 {
+	c := &partitionNumbers
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.p) == 0 {
+		c.p = append(c.p, big.NewInt(1))
+		c.lp = append(c.lp, 0)
+	}
+	for k := len(c.p); k <= n; k++ {
+		s := new(big.Int)
+		for i := 1; ; i++ {
+			g := i * (3*i - 1) / 2
+			if g > k {
+				break
+			}
+			if i%2 == 1 {
+				s.Add(s, c.p[k-g])
+			} else {
+				s.Sub(s, c.p[k-g])
+			}
+			if g+i <= k {
+				if i%2 == 1 {
+					s.Add(s, c.p[k-g-i])
+				} else {
+					s.Sub(s, c.p[k-g-i])
+				}
+			}
+		}
+		// keep the top 64 bits, which is more than float64 can represent
+		shift := s.BitLen() - 64
+		if shift < 0 {
+			shift = 0
+		}
+		c.p = append(c.p, s)
+		c.lp = append(c.lp, math.Log(float64(new(big.Int).Rsh(s, uint(shift)).Uint64()))+float64(shift)*math.Ln2)
+	}
+	// elements up to n are never modified again, so the result can be used without the lock
+	return c.lp[: n+1 : n+1]
+}
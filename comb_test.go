@@ -0,0 +1,294 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"fmt"
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"sync"
+	"testing"
+)
+
+func isPerm(p []int) bool {
+	seen := make([]bool, len(p))
+	for _, v := range p {
+		if v < 0 || v >= len(p) || seen[v] {
+			return false
+		}
+		seen[v] = true
+	}
+	return true
+}
+
+func TestRand_RandomBST(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		if p := r.RandomBST(n); len(p) != n || !isPerm(p) {
+			t.Fatalf("%v is not a permutation of [0, %v)", p, n)
+		}
+	})
+}
+
+func TestRand_RandomBinaryTree(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		left, right := r.RandomBinaryTree(n)
+		if len(left) != n || len(right) != n {
+			t.Fatalf("got %v/%v children instead of %v", len(left), len(right), n)
+		}
+		// preorder traversal from the root must visit nodes 0, 1, ..., n-1 exactly once
+		next := 0
+		var visit func(v int)
+		visit = func(v int) {
+			if v != next {
+				t.Fatalf("node %v visited at preorder position %v", v, next)
+			}
+			next++
+			if left[v] >= 0 {
+				visit(left[v])
+			}
+			if right[v] >= 0 {
+				visit(right[v])
+			}
+		}
+		if n > 0 {
+			visit(0)
+		}
+		if next != n {
+			t.Fatalf("visited %v nodes instead of %v", next, n)
+		}
+	})
+}
+
+func TestRand_RandomBinaryTree_Uniform(t *testing.T) {
+	const (
+		n      = 4
+		shapes = 14 // Catalan number C(4)
+		N      = 140000
+	)
+	r := rand.New(1)
+	counts := map[string]int{}
+	for i := 0; i < N; i++ {
+		left, right := r.RandomBinaryTree(n)
+		counts[fmt.Sprint(left, right)]++
+	}
+	if len(counts) != shapes {
+		t.Fatalf("got %v shapes instead of %v", len(counts), shapes)
+	}
+	want := float64(N) / shapes
+	for shape, c := range counts {
+		if math.Abs(float64(c)-want) > 5*math.Sqrt(want) {
+			t.Errorf("shape %v generated %v times instead of ~%v", shape, c, want)
+		}
+	}
+}
+
+func inversions(p []int) int {
+	n := 0
+	for i := range p {
+		for j := i + 1; j < len(p); j++ {
+			if p[i] > p[j] {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func TestRand_PermWithInversions(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, 200).Draw(t, "n").(int)
+		k := rapid.IntRange(0, n*(n-1)/2).Draw(t, "k").(int)
+		p := r.PermWithInversions(n, k)
+		if len(p) != n || !isPerm(p) {
+			t.Fatalf("%v is not a permutation of [0, %v)", p, n)
+		}
+		if inv := inversions(p); inv != k {
+			t.Fatalf("got %v inversions instead of %v in %v", inv, k, p)
+		}
+	})
+}
+
+func TestRand_IntPartition(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		parts := r.IntPartition(n)
+		sum := 0
+		for i, v := range parts {
+			if v <= 0 || (i > 0 && v > parts[i-1]) {
+				t.Fatalf("got invalid partition %v", parts)
+			}
+			sum += v
+		}
+		if sum != n {
+			t.Fatalf("got partition %v of %v instead of %v", parts, sum, n)
+		}
+	})
+}
+
+func TestRand_IntPartition_Uniform(t *testing.T) {
+	const (
+		n          = 6
+		partitions = 11 // p(6)
+		N          = 110000
+	)
+	r := rand.New(1)
+	counts := map[string]int{}
+	for i := 0; i < N; i++ {
+		counts[fmt.Sprint(r.IntPartition(n))]++
+	}
+	if len(counts) != partitions {
+		t.Fatalf("got %v partitions instead of %v", len(counts), partitions)
+	}
+	want := float64(N) / partitions
+	for p, c := range counts {
+		if math.Abs(float64(c)-want) > 5*math.Sqrt(want) {
+			t.Errorf("partition %v generated %v times instead of ~%v", p, c, want)
+		}
+	}
+}
+
+func TestRand_IntPartition_Large(t *testing.T) {
+	// p(n) overflows float64 for n above about 76000, and the pentagonal number recurrence
+	// loses all precision in floating point long before that
+	const n = 80000
+	r := rand.New(1)
+	parts := r.IntPartition(n)
+	sum, ones := 0, 0
+	for _, v := range parts {
+		sum += v
+		if v == 1 {
+			ones++
+		}
+	}
+	if sum != n {
+		t.Fatalf("got partition of %v instead of %v", sum, n)
+	}
+	// the expected number of ones is p(n-1)/p(n) + p(n-2)/p(n) + ... ≈ sqrt(6n)/pi ≈ 220
+	if ones > 2000 {
+		t.Errorf("got %v parts equal to 1", ones)
+	}
+}
+
+func TestRand_IntPartition_Concurrent(t *testing.T) {
+	// the cached partition numbers are shared and grown by concurrent calls
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			r := rand.New(uint64(g))
+			for n := g; n < 2000; n += 97 {
+				sum := 0
+				for _, v := range r.IntPartition(n) {
+					sum += v
+				}
+				if sum != n {
+					t.Errorf("got partition of %v instead of %v", sum, n)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestRand_RandomTree(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		parent := r.RandomTree(n)
+		if len(parent) != n {
+			t.Fatalf("got %v nodes instead of %v", len(parent), n)
+		}
+		if n > 0 && parent[0] != -1 {
+			t.Fatalf("got parent %v of the root", parent[0])
+		}
+		// every node but the root has a parent, so there are n-1 edges;
+		// following parents from any node must reach the root in less than n steps
+		for i := 1; i < n; i++ {
+			u := i
+			for steps := 0; u != 0; steps++ {
+				if parent[u] < 0 || parent[u] >= n || steps >= n {
+					t.Fatalf("node %v does not reach the root in %v", i, parent)
+				}
+				u = parent[u]
+			}
+		}
+	})
+}
+
+func TestRand_RandomTree_Uniform(t *testing.T) {
+	const (
+		n     = 4
+		trees = 16 // n^(n-2)
+		N     = 160000
+	)
+	r := rand.New(1)
+	counts := map[string]int{}
+	for i := 0; i < N; i++ {
+		counts[fmt.Sprint(r.RandomTree(n))]++
+	}
+	if len(counts) != trees {
+		t.Fatalf("got %v trees instead of %v", len(counts), trees)
+	}
+	want := float64(N) / trees
+	for tree, c := range counts {
+		if math.Abs(float64(c)-want) > 5*math.Sqrt(want) {
+			t.Errorf("tree %v generated %v times instead of ~%v", tree, c, want)
+		}
+	}
+}
+
+func TestRand_Involution(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		p := r.Involution(n)
+		if len(p) != n || !isPerm(p) {
+			t.Fatalf("got %v which is not a permutation of %v elements", p, n)
+		}
+		for i := range p {
+			if p[p[i]] != i {
+				t.Fatalf("got %v which is not an involution", p)
+			}
+		}
+	})
+}
+
+func TestRand_Involution_Uniform(t *testing.T) {
+	const (
+		n           = 5
+		involutions = 26 // I(5)
+		N           = 130000
+	)
+	r := rand.New(1)
+	counts := map[string]int{}
+	for i := 0; i < N; i++ {
+		counts[fmt.Sprint(r.Involution(n))]++
+	}
+	if len(counts) != involutions {
+		t.Fatalf("got %v involutions instead of %v", len(counts), involutions)
+	}
+	want := float64(N) / involutions
+	for p, c := range counts {
+		if math.Abs(float64(c)-want) > 5*math.Sqrt(want) {
+			t.Errorf("involution %v generated %v times instead of ~%v", p, c, want)
+		}
+	}
+}
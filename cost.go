@@ -0,0 +1,47 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+// Float64Cost is like [Rand.Float64], but also returns the number of 64-bit values
+// consumed from the underlying generator, which is always 1.
+func (r *Rand) Float64Cost() (float64, int) {
+	w := r.w
+	return r.Float64(), int(r.w - w)
+}
+
+// NormFloat64Cost is like [Rand.NormFloat64], but also returns the number of 64-bit values
+// consumed from the underlying generator. The number varies because of rejection sampling.
+func (r *Rand) NormFloat64Cost() (float64, int) {
+	w := r.w
+	return r.NormFloat64(), int(r.w - w)
+}
+
+// ExpFloat64Cost is like [Rand.ExpFloat64], but also returns the number of 64-bit values
+// consumed from the underlying generator. The number varies because of rejection sampling.
+func (r *Rand) ExpFloat64Cost() (float64, int) {
+	w := r.w
+	return r.ExpFloat64(), int(r.w - w)
+}
+
+// CountingRand is a [Rand] that counts the 64-bit values generated by the underlying generator.
+// Methods that consume less than 64 bits, such as [Rand.Uint32], share buffered values
+// and do not advance the count on every call.
+type CountingRand struct {
+	*Rand
+	start uint64
+}
+
+// NewCountingRand returns a CountingRand wrapping r, with the count starting at 0.
+// Changing the state of r with [Rand.Seed] or [Rand.UnmarshalBinary] invalidates the count.
+func NewCountingRand(r *Rand) *CountingRand {
+	return &CountingRand{Rand: r, start: r.w}
+}
+
+// Count returns the number of 64-bit values generated since c was created.
+func (c *CountingRand) Count() uint64 {
+	return c.w - c.start
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"bytes"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+func testCost(t *rapid.T, f func(r *rand.Rand) (float64, int)) int {
+	s := rapid.Uint64().Draw(t, "s").(uint64)
+	skip := rapid.IntRange(0, small).Draw(t, "skip").(int)
+	r1 := rand.New(s)
+	for i := 0; i < skip; i++ {
+		r1.Uint64()
+	}
+	r2 := *r1
+	_, c := f(r1)
+	for i := 0; i < c; i++ {
+		r2.Uint64()
+	}
+	data1, _ := r1.MarshalBinary()
+	data2, _ := r2.MarshalBinary()
+	if !bytes.Equal(data1, data2) {
+		t.Fatalf("state differs after consuming %v values", c)
+	}
+	return c
+}
+
+func TestRand_Float64Cost(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		if c := testCost(t, (*rand.Rand).Float64Cost); c != 1 {
+			t.Fatalf("got cost %v instead of 1", c)
+		}
+	})
+}
+
+func TestRand_NormFloat64Cost(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		testCost(t, (*rand.Rand).NormFloat64Cost)
+	})
+}
+
+func TestRand_ExpFloat64Cost(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		testCost(t, (*rand.Rand).ExpFloat64Cost)
+	})
+}
+
+func TestRand_NormFloat64Cost_Varies(t *testing.T) {
+	r := rand.New(1)
+	r2 := rand.New(1)
+	counts := map[int]int{}
+	for i := 0; i < 10000; i++ {
+		f1, c := r.NormFloat64Cost()
+		f2 := r2.NormFloat64()
+		if f1 != f2 {
+			t.Fatalf("got %v instead of %v", f1, f2)
+		}
+		counts[c]++
+	}
+	if counts[1] == 0 || len(counts) < 2 {
+		t.Fatalf("unexpected cost distribution %v", counts)
+	}
+}
+
+func TestCountingRand(t *testing.T) {
+	c := rand.NewCountingRand(rand.New(1))
+	if n := c.Count(); n != 0 {
+		t.Fatalf("got initial count %v", n)
+	}
+	c.Uint64()
+	if n := c.Count(); n != 1 {
+		t.Fatalf("got count %v instead of 1 after Uint64", n)
+	}
+	c.Float64()
+	if n := c.Count(); n != 2 {
+		t.Fatalf("got count %v instead of 2 after Float64", n)
+	}
+	counts := map[uint64]int{}
+	for i := 0; i < 10000; i++ {
+		n := c.Count()
+		c.ExpFloat64()
+		counts[c.Count()-n]++
+	}
+	if counts[0] != 0 || counts[1] == 0 || len(counts) < 2 {
+		t.Fatalf("unexpected ExpFloat64 count distribution %v", counts)
+	}
+}
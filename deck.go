@@ -0,0 +1,45 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+// A Deck is a shuffled deck of cards numbered from 0 to size-1, dealt from the top without replacement.
+type Deck struct {
+	r     *Rand
+	cards []int // remaining cards, top first
+}
+
+// NewDeck returns a deck of size cards shuffled using r, which is also used by [Deck.Cut].
+// It panics if size < 0.
+func NewDeck(r *Rand, size int) *Deck {
+	if size < 0 {
+		panic("invalid argument to NewDeck")
+	}
+	return &Deck{r: r, cards: r.Perm(size)}
+}
+
+// Deal removes k cards from the top of the deck and returns them, top first.
+// It panics if k < 0 or k > d.Remaining().
+func (d *Deck) Deal(k int) []int {
+	if k < 0 || k > len(d.cards) {
+		panic("invalid argument to Deal")
+	}
+	s := append([]int(nil), d.cards[:k]...)
+	d.cards = d.cards[k:]
+	return s
+}
+
+// Cut moves a pseudo-random number of cards, chosen uniformly in the closed interval [0, d.Remaining()],
+// from the top of the deck to the bottom, preserving their order.
+func (d *Deck) Cut() {
+	k := int(d.r.Uint64n(uint64(len(d.cards)) + 1))
+	d.cards = append(d.cards[k:len(d.cards):len(d.cards)], d.cards[:k]...)
+}
+
+// Remaining returns the number of cards left in the deck.
+func (d *Deck) Remaining() int {
+	return len(d.cards)
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"sort"
+	"testing"
+)
+
+func TestDeck(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		size := rapid.IntRange(0, small).Draw(t, "size").(int)
+		d := rand.NewDeck(rand.New(s), size)
+		var dealt []int
+		for d.Remaining() > 0 {
+			k := rapid.IntRange(0, d.Remaining()).Draw(t, "k").(int)
+			cards := d.Deal(k)
+			if len(cards) != k {
+				t.Fatalf("dealt %v cards instead of %v", len(cards), k)
+			}
+			dealt = append(dealt, cards...)
+			if rapid.Bool().Draw(t, "cut").(bool) {
+				d.Cut()
+			}
+			if d.Remaining() != size-len(dealt) {
+				t.Fatalf("got %v remaining cards instead of %v", d.Remaining(), size-len(dealt))
+			}
+		}
+		if !isPerm(dealt) {
+			t.Fatalf("dealt cards %v are not a permutation", dealt)
+		}
+	})
+}
+
+func TestDeck_Cut(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		size := rapid.IntRange(0, small).Draw(t, "size").(int)
+		d := rand.NewDeck(rand.New(s), size)
+		d.Cut()
+		cards := d.Deal(d.Remaining())
+		sort.Ints(cards)
+		for i, c := range cards {
+			if c != i {
+				t.Fatalf("cut deck %v is missing card %v", cards, i)
+			}
+		}
+	})
+}
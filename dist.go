@@ -0,0 +1,548 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import (
+	"math"
+	"math/bits"
+)
+
+// DiscreteGaussian returns an int64 drawn from the discrete Gaussian distribution centered at 0,
+// where the probability of k is proportional to exp(-k²/(2σ²)). It panics if sigma <= 0.
+//
+// DiscreteGaussian is not suitable for cryptographic use.
+func (r *Rand) DiscreteGaussian(sigma float64) int64 {
+	if !(sigma > 0) || math.IsInf(sigma, 1) {
+		panic("invalid argument to DiscreteGaussian")
+	}
+	// candidates are continuous Gaussian values rounded to the nearest integer; the probability
+	// of proposing k is g(k) = P(k-1/2 <= σZ < k+1/2), and k is accepted with probability
+	// proportional to exp(-k²/(2σ²)) / g(k), which attains its maximum at k = 0.
+	s := sigma * math.Sqrt2
+	g0 := math.Erf(0.5 / s)
+	for {
+		k := math.Round(r.NormFloat64() * sigma)
+		a := math.Abs(k)
+		g := 0.5 * (math.Erfc((a-0.5)/s) - math.Erfc((a+0.5)/s))
+		if g > 0 && r.Float64()*g < g0*math.Exp(-a*a/(2*sigma*sigma)) {
+			return int64(k)
+		}
+	}
+}
+
+// NegativeBinomial returns an int64 drawn from the negative binomial distribution:
+// the number of failures before r0 successes in a sequence of Bernoulli trials with
+// success probability p. Non-integer r0 is supported, as the value is drawn from
+// a Poisson distribution whose mean is drawn from a gamma distribution.
+// It panics if r0 <= 0 or p is outside of (0, 1).
+func (r *Rand) NegativeBinomial(r0 float64, p float64) int64 {
+	if !(r0 > 0) || math.IsInf(r0, 1) || !(p > 0 && p < 1) {
+		panic("invalid argument to NegativeBinomial")
+	}
+	return r.poisson(r.gamma(r0) * (1 - p) / p)
+}
+
+// gamma returns a value drawn from the gamma distribution with the given shape and scale 1.
+func (r *Rand) gamma(shape float64) float64 {
+	// "A Simple Method for Generating Gamma Variables" by George Marsaglia and Wai Wan Tsang
+	if shape < 1 {
+		return r.gamma(shape+1) * math.Pow(r.Float64(), 1/shape)
+	}
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := r.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := r.Float64()
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+// logGamma returns the logarithm of a value drawn from the gamma distribution with the given
+// shape and scale 1. Unlike gamma, it does not underflow for small shapes.
+func (r *Rand) logGamma(shape float64) float64 {
+	if shape < 1 {
+		return math.Log(r.gamma(shape+1)) + math.Log(r.float64Pos())/shape
+	}
+	return math.Log(r.gamma(shape))
+}
+
+// poisson returns a value drawn from the Poisson distribution with the given mean.
+func (r *Rand) poisson(lambda float64) int64 {
+	if lambda < 10 {
+		// multiplication of uniforms, expected lambda+1 iterations
+		l := math.Exp(-lambda)
+		k := int64(0)
+		for p := r.Float64(); p > l; p *= r.Float64() {
+			k++
+		}
+		return k
+	}
+	// "The transformed rejection method for generating Poisson random variables" by Wolfgang Hörmann
+	slam := math.Sqrt(lambda)
+	loglam := math.Log(lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invalpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := r.Float64() - 0.5
+		v := r.Float64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return int64(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		lg, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invalpha)-math.Log(a/(us*us)+b) <= -lambda+k*loglam-lg {
+			return int64(k)
+		}
+	}
+}
+
+// Hypergeometric returns an int64 drawn from the hypergeometric distribution:
+// the number of successes when drawing n items without replacement from
+// a population of N items that contains K successes.
+// It panics unless 0 <= K <= N and 0 <= n <= N.
+func (r *Rand) Hypergeometric(N, K, n int64) int64 {
+	if K < 0 || K > N || n < 0 || n > N {
+		panic("invalid argument to Hypergeometric")
+	}
+	// reduce to K <= N/2 and n <= N/2 using the symmetries of the distribution
+	if 2*n > N {
+		return K - r.Hypergeometric(N, K, N-n)
+	}
+	if 2*K > N {
+		return n - r.Hypergeometric(N, N-K, n)
+	}
+	hi := K
+	if n < hi {
+		hi = n
+	}
+	u := r.Float64()
+	// ratio returns P(k+1) / P(k)
+	ratio := func(k int64) float64 {
+		return float64(K-k) * float64(n-k) / (float64(k+1) * float64(N-K-n+k+1))
+	}
+	if n < 32 {
+		// sequential inversion from 0; P(0) >= 2^-n after the reduction
+		p := math.Exp(logChoose(N-K, n) - logChoose(N, n))
+		k := int64(0)
+		for u -= p; u >= 0 && k < hi; u -= p {
+			p *= ratio(k)
+			k++
+		}
+		return k
+	}
+	// inversion by searching outwards from the mode, alternating between the two sides
+	m := int64((float64(n) + 1) * (float64(K) + 1) / (float64(N) + 2))
+	if m > hi {
+		m = hi
+	}
+	lo := n - (N - K)
+	if lo < 0 {
+		lo = 0
+	}
+	pm := math.Exp(logChoose(K, m) + logChoose(N-K, n-m) - logChoose(N, n))
+	if u -= pm; u < 0 {
+		return m
+	}
+	a, b := m, m
+	pa, pb := pm, pm
+	for a > lo || b < hi {
+		if b < hi {
+			pb *= ratio(b)
+			b++
+			if u -= pb; u < 0 {
+				return b
+			}
+		}
+		if a > lo {
+			a--
+			pa /= ratio(a)
+			if u -= pa; u < 0 {
+				return a
+			}
+		}
+	}
+	return m // rounding errors
+}
+
+// logChoose returns the natural logarithm of the binomial coefficient (n choose k).
+func logChoose(n, k int64) float64 {
+	a, _ := math.Lgamma(float64(n) + 1)
+	b, _ := math.Lgamma(float64(k) + 1)
+	c, _ := math.Lgamma(float64(n-k) + 1)
+	return a - b - c
+}
+
+// Simplex returns a point drawn uniformly from the standard (n-1)-simplex: n non-negative
+// values that sum to 1. This is equivalent to the Dirichlet distribution with all
+// parameters equal to 1. It panics if n < 1.
+func (r *Rand) Simplex(n int) []float64 {
+	if n < 1 {
+		panic("invalid argument to Simplex")
+	}
+	// normalized independent standard exponentials
+	p := make([]float64, n)
+	sum := 0.0
+	for i := range p {
+		p[i] = r.ExpFloat64()
+		sum += p[i]
+	}
+	for i := range p {
+		p[i] /= sum
+	}
+	return p
+}
+
+// NormFloat64Into fills dst with standard normally distributed values, exactly like calling
+// [Rand.NormFloat64] len(dst) times. The fast path of the ziggurat algorithm, taken for more
+// than 99% of values, is inlined into the loop without allocating or calling functions.
+func (r *Rand) NormFloat64Into(dst []float64) {
+	for k := range dst {
+		v := r.next64()
+		j := int64(v) >> 11 // Possibly negative
+		i := v & 0xFF
+		if absInt64(j) < kn[i] {
+			dst[k] = float64(j) * wn[i]
+			continue
+		}
+		dst[k] = r.normSlow(j, i)
+	}
+}
+
+// normSlow completes a NormFloat64 iteration that failed the fast path of the ziggurat algorithm.
+func (r *Rand) normSlow(j int64, i uint64) float64 {
+	x := float64(j) * wn[i]
+	if i == 0 {
+		for {
+			x = -math.Log(r.Float64()) * (1.0 / rn)
+			y := -math.Log(r.Float64())
+			if y+y >= x*x {
+				break
+			}
+		}
+		if j > 0 {
+			return rn + x
+		}
+		return -rn - x
+	}
+	if fn[i]+r.Float64()*(fn[i-1]-fn[i]) < math.Exp(-.5*x*x) {
+		return x
+	}
+	return r.NormFloat64()
+}
+
+// SortedFloat64s returns n values in ascending order in the half-open interval [0.0, 1.0),
+// distributed like n sorted uniform values. Instead of sorting, it uses the fact that the
+// normalized cumulative sums of n+1 independent exponentials are distributed like the order
+// statistics of n uniforms, which takes O(n) time. It panics if n < 0.
+func (r *Rand) SortedFloat64s(n int) []float64 {
+	if n < 0 {
+		panic("invalid argument to SortedFloat64s")
+	}
+	s := make([]float64, n)
+	sum := 0.0
+	for i := range s {
+		sum += r.ExpFloat64()
+		s[i] = sum
+	}
+	sum += r.ExpFloat64()
+	for i := range s {
+		s[i] /= sum
+		if s[i] >= 1 { // rounding errors
+			s[i] = math.Nextafter(1, 0)
+		}
+	}
+	return s
+}
+
+// Stratified returns n values where the i-th one is uniformly distributed in the stratum
+// [i/n, (i+1)/n), for variance reduction in Monte Carlo integration. The values are
+// in ascending order; shuffle them with [ShuffleSlice] if the order matters. It panics if n < 0.
+func (r *Rand) Stratified(n int) []float64 {
+	if n < 0 {
+		panic("invalid argument to Stratified")
+	}
+	s := make([]float64, n)
+	for i := range s {
+		hi := float64(i+1) / float64(n)
+		s[i] = (float64(i) + r.Float64()) / float64(n)
+		if s[i] >= hi { // rounding errors
+			s[i] = math.Nextafter(hi, 0)
+		}
+	}
+	return s
+}
+
+// BiasedSimplex returns a point of the standard (n-1)-simplex drawn from the symmetric Dirichlet
+// distribution with parameter concentration: n non-negative values that sum to 1. Concentration of 1
+// is the uniform distribution over the simplex, like [Rand.Simplex]; smaller values concentrate
+// the points near the corners, where one value dominates, and larger values near the center.
+// It panics if n < 1 or concentration <= 0.
+func (r *Rand) BiasedSimplex(n int, concentration float64) []float64 {
+	if n < 1 || !(concentration > 0) || math.IsInf(concentration, 1) {
+		panic("invalid argument to BiasedSimplex")
+	}
+	// normalized independent gamma variates, computed in log space to avoid underflow
+	p := make([]float64, n)
+	max := math.Inf(-1)
+	for i := range p {
+		p[i] = r.logGamma(concentration)
+		max = math.Max(max, p[i])
+	}
+	sum := 0.0
+	for i := range p {
+		p[i] = math.Exp(p[i] - max)
+		sum += p[i]
+	}
+	for i := range p {
+		p[i] /= sum
+	}
+	return p
+}
+
+// TruncatedNormal returns a float64 drawn from the normal distribution with the given mean
+// and standard deviation, conditioned to lie in the closed interval [lo, hi]. Unlike repeated
+// sampling until the value is inside the interval, it remains efficient when the interval
+// is far in the tail of the distribution. It panics if lo >= hi or stddev <= 0.
+func (r *Rand) TruncatedNormal(mean, stddev, lo, hi float64) float64 {
+	if !(lo < hi) || !(stddev > 0) {
+		panic("invalid argument to TruncatedNormal")
+	}
+	a, b := (lo-mean)/stddev, (hi-mean)/stddev
+	var z float64
+	if b <= 0 {
+		z = -r.truncatedStdNormal(-b, -a)
+	} else {
+		z = r.truncatedStdNormal(a, b)
+	}
+	x := mean + z*stddev
+	// guard against rounding errors
+	if x < lo {
+		return lo
+	}
+	if x > hi {
+		return hi
+	}
+	return x
+}
+
+// truncatedStdNormal returns a standard normal variate conditioned to lie in [a, b], b > 0.
+func (r *Rand) truncatedStdNormal(a, b float64) float64 {
+	// "Simulation of truncated normal variables" by Christian P. Robert
+	if a <= 0 {
+		if b-a >= math.Sqrt(2*math.Pi) {
+			for {
+				z := r.NormFloat64()
+				if a <= z && z <= b {
+					return z
+				}
+			}
+		}
+		return r.truncatedStdNormalUniform(a, b, 0)
+	}
+	alpha := (a + math.Sqrt(a*a+4)) / 2
+	if b-a < 2*math.Sqrt(math.E)/(a+math.Sqrt(a*a+4))*math.Exp((a*a-a*math.Sqrt(a*a+4))/4) {
+		return r.truncatedStdNormalUniform(a, b, a*a)
+	}
+	for {
+		z := a + r.ExpFloat64()/alpha
+		if z <= b && r.Float64() <= math.Exp(-(z-alpha)*(z-alpha)/2) {
+			return z
+		}
+	}
+}
+
+func (r *Rand) truncatedStdNormalUniform(a, b float64, c float64) float64 {
+	for {
+		z := a + (b-a)*r.Float64()
+		if r.Float64() <= math.Exp((c-z*z)/2) {
+			return z
+		}
+	}
+}
+
+// Laplace returns a float64 drawn from the Laplace (double exponential) distribution
+// with location mu and scale b. It panics if b <= 0.
+func (r *Rand) Laplace(mu, b float64) float64 {
+	if !(b > 0) {
+		panic("invalid argument to Laplace")
+	}
+	// exponential magnitude with a random sign
+	x := r.ExpFloat64() * b
+	if r.Uint32()&1 == 0 {
+		return mu - x
+	}
+	return mu + x
+}
+
+// Rayleigh returns a float64 drawn from the Rayleigh distribution with scale sigma.
+// It panics if sigma <= 0.
+func (r *Rand) Rayleigh(sigma float64) float64 {
+	if !(sigma > 0) {
+		panic("invalid argument to Rayleigh")
+	}
+	return sigma * math.Sqrt(-2*math.Log(r.float64Pos()))
+}
+
+// SampleInverseCDF returns invCDF(u), where u is a pseudo-random float64 in the half-open
+// interval [0, 1). This is inverse transform sampling: if invCDF is the inverse of the cumulative
+// distribution function of some distribution, the result is drawn from that distribution.
+func (r *Rand) SampleInverseCDF(invCDF func(u float64) float64) float64 {
+	return invCDF(r.Float64())
+}
+
+// SampleInverseCDFPos is like SampleInverseCDF, but u is in the open interval (0, 1).
+// Use it for distributions whose inverse CDF diverges at 0, like invCDF(u) = -math.Log(u).
+func (r *Rand) SampleInverseCDFPos(invCDF func(u float64) float64) float64 {
+	return invCDF(r.float64Pos())
+}
+
+// PowerLawInt returns an int64 drawn from the discrete power-law distribution with the given exponent
+// and no upper bound, with P(x) proportional to x^-exponent for x >= xmin. It uses the approximation
+// of rounding a continuous power-law sample, which is accurate for xmin of about 6 or more.
+// Results that do not fit into an int64 are clamped to math.MaxInt64. It panics if exponent <= 1 or xmin < 1.
+func (r *Rand) PowerLawInt(exponent float64, xmin int64) int64 {
+	if !(exponent > 1) || xmin < 1 {
+		panic("invalid argument to PowerLawInt")
+	}
+	x := math.Floor((float64(xmin)-0.5)*math.Pow(r.float64Pos(), -1/(exponent-1)) + 0.5)
+	if !(x < math.MaxInt64) {
+		return math.MaxInt64
+	}
+	if x < float64(xmin) {
+		return xmin
+	}
+	return int64(x)
+}
+
+// RecencyBiasedIndex returns, as an int, a pseudo-random index in the half-open interval [0, n),
+// where lower (more recent) indices are favored: the probability of index i is proportional to
+// 2^(-i/halfLife), so it halves every halfLife indices. It panics if n <= 0 or halfLife <= 0.
+func (r *Rand) RecencyBiasedIndex(n int, halfLife float64) int {
+	if n <= 0 || !(halfLife > 0) {
+		panic("invalid argument to RecencyBiasedIndex")
+	}
+	return r.truncatedGeometric(-math.Ln2/halfLife, n-1)
+}
+
+// Kumaraswamy returns a float64 drawn from the Kumaraswamy distribution with shape parameters
+// a and b, using its closed-form inverse CDF. Its shape is similar to the beta distribution,
+// but it is cheaper to sample. The result is in the open interval (0, 1): for extreme shape
+// parameters, values that round to 0 or 1 are replaced with the nearest float64 inside the interval.
+// It panics if a <= 0 or b <= 0.
+func (r *Rand) Kumaraswamy(a, b float64) float64 {
+	if !(a > 0) || !(b > 0) {
+		panic("invalid argument to Kumaraswamy")
+	}
+	// 1 - (1-U)^(1/b) with U uniform is distributed like 1 - U^(1/b)
+	x := math.Pow(-math.Expm1(math.Log(r.float64Pos())/b), 1/a)
+	return math.Max(math.SmallestNonzeroFloat64, math.Min(x, math.Nextafter(1, 0)))
+}
+
+// CoinFlips returns the number of heads in k fair coin flips, which is binomially distributed
+// with parameters k and 0.5. It counts the set bits of ceil(k/64) pseudo-random 64-bit values,
+// ignoring the excess bits of the last one. It panics if k < 0.
+func (r *Rand) CoinFlips(k int) int {
+	if k < 0 {
+		panic("invalid argument to CoinFlips")
+	}
+	n := 0
+	for ; k >= 64; k -= 64 {
+		n += bits.OnesCount64(r.next64())
+	}
+	if k > 0 {
+		n += bits.OnesCount64(r.next64() & (1<<k - 1))
+	}
+	return n
+}
+
+// MaxOfUniforms returns, as a float64, the maximum of k independent uniformly distributed
+// pseudo-random numbers in the half-open interval [0.0, 1.0), in O(1) time by inverting
+// its CDF, u^k. It panics if k < 1.
+func (r *Rand) MaxOfUniforms(k int) float64 {
+	if k < 1 {
+		panic("invalid argument to MaxOfUniforms")
+	}
+	return math.Pow(r.Float64(), 1/float64(k))
+}
+
+// MinOfUniforms returns, as a float64, the minimum of k independent uniformly distributed
+// pseudo-random numbers in the half-open interval [0.0, 1.0), in O(1) time by inverting
+// its CDF, 1 - (1-u)^k. It panics if k < 1.
+func (r *Rand) MinOfUniforms(k int) float64 {
+	if k < 1 {
+		panic("invalid argument to MinOfUniforms")
+	}
+	// 1 - (1-U)^(1/k), computed without cancellation for large k
+	return -math.Expm1(math.Log1p(-r.Float64()) / float64(k))
+}
+
+// beta returns a value drawn from the beta distribution with shape parameters a and b,
+// as the ratio G(a) / (G(a) + G(b)) of gamma variates computed in log space.
+func (r *Rand) beta(a, b float64) float64 {
+	la, lb := r.logGamma(a), r.logGamma(b)
+	return 1 / (1 + math.Exp(lb-la))
+}
+
+// Beta4 returns a float64 drawn from the four-parameter beta distribution: the beta distribution
+// with shape parameters alpha and beta, scaled and shifted to the open interval (lo, hi).
+// Values that round to either endpoint are rejected. It panics if alpha <= 0, beta <= 0,
+// lo or hi is not finite, or there is no float64 strictly between lo and hi.
+func (r *Rand) Beta4(alpha, beta, lo, hi float64) float64 {
+	if !(alpha > 0) || !(beta > 0) || math.IsInf(alpha, 1) || math.IsInf(beta, 1) ||
+		!(lo < hi) || math.IsInf(lo, 0) || math.IsInf(hi, 0) || math.Nextafter(lo, hi) == hi {
+		panic("invalid argument to Beta4")
+	}
+	for {
+		u := r.beta(alpha, beta)
+		x := lo*(1-u) + hi*u
+		if lo < x && x < hi {
+			return x
+		}
+	}
+}
+
+// Gumbel returns a float64 drawn from the Gumbel distribution with location mu and scale beta.
+// Adding independent standard Gumbel values (mu = 0, beta = 1) to logits and taking the argmax
+// samples from the softmax of the logits (the Gumbel-max trick). It panics if beta <= 0.
+func (r *Rand) Gumbel(mu, beta float64) float64 {
+	if !(beta > 0) {
+		panic("invalid argument to Gumbel")
+	}
+	return mu - beta*math.Log(-math.Log(r.float64Pos()))
+}
+
+// RejectionSample returns a float64 drawn from the distribution on [lo, hi) with density proportional to pdf,
+// using rejection sampling: x is drawn uniformly from [lo, hi), and accepted if Float64()*pdfMax <= pdf(x).
+// pdfMax must bound pdf on [lo, hi) from above; the expected number of pdf calls is pdfMax*(hi-lo)
+// divided by the integral of pdf over [lo, hi). It panics if pdfMax <= 0 or lo >= hi.
+func (r *Rand) RejectionSample(pdf func(x float64) float64, lo, hi, pdfMax float64) float64 {
+	if !(pdfMax > 0) || !(lo < hi) {
+		panic("invalid argument to RejectionSample")
+	}
+	for {
+		x := lo + (hi-lo)*r.Float64()
+		if x >= hi { // rounding errors
+			continue
+		}
+		if r.Float64()*pdfMax <= pdf(x) {
+			return x
+		}
+	}
+}
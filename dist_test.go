@@ -0,0 +1,669 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"fmt"
+	"math"
+	"pgregory.net/rand"
+	"sort"
+	"testing"
+)
+
+func meanVariance(samples []float64) (float64, float64) {
+	var m, v float64
+	for _, s := range samples {
+		m += s
+	}
+	m /= float64(len(samples))
+	for _, s := range samples {
+		v += (s - m) * (s - m)
+	}
+	return m, v / float64(len(samples)-1)
+}
+
+func TestRand_DiscreteGaussian(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	for _, sigma := range []float64{1, 2.5, 10} {
+		samples := make([]float64, N)
+		pos, neg := 0, 0
+		for i := range samples {
+			k := r.DiscreteGaussian(sigma)
+			samples[i] = float64(k)
+			if k > 0 {
+				pos++
+			} else if k < 0 {
+				neg++
+			}
+		}
+		m, v := meanVariance(samples)
+		if math.Abs(m) > 5*sigma/math.Sqrt(N) {
+			t.Errorf("sigma %v: got mean %v instead of 0", sigma, m)
+		}
+		if math.Abs(v-sigma*sigma) > 0.02*sigma*sigma {
+			t.Errorf("sigma %v: got variance %v instead of %v", sigma, v, sigma*sigma)
+		}
+		if d := math.Abs(float64(pos - neg)); d > 5*math.Sqrt(float64(pos+neg)) {
+			t.Errorf("sigma %v: got %v positive and %v negative values", sigma, pos, neg)
+		}
+	}
+}
+
+func TestRand_NegativeBinomial(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	for _, c := range []struct{ r0, p float64 }{{1, 0.5}, {2.5, 0.3}, {0.7, 0.9}, {10, 0.05}} {
+		samples := make([]float64, N)
+		for i := range samples {
+			k := r.NegativeBinomial(c.r0, c.p)
+			if k < 0 {
+				t.Fatalf("got negative value %v", k)
+			}
+			samples[i] = float64(k)
+		}
+		m, v := meanVariance(samples)
+		want, wantV := c.r0*(1-c.p)/c.p, c.r0*(1-c.p)/(c.p*c.p)
+		if math.Abs(m-want) > 5*math.Sqrt(wantV/N) {
+			t.Errorf("r0 %v, p %v: got mean %v instead of %v", c.r0, c.p, m, want)
+		}
+		if math.Abs(v-wantV) > 0.05*wantV {
+			t.Errorf("r0 %v, p %v: got variance %v instead of %v", c.r0, c.p, v, wantV)
+		}
+	}
+}
+
+func TestRand_Hypergeometric(t *testing.T) {
+	const S = 100000
+	r := rand.New(1)
+	for _, c := range []struct{ N, K, n int64 }{{10, 3, 4}, {50, 40, 7}, {1000, 300, 900}, {100000, 5000, 20000}, {1 << 40, 1 << 39, 1 << 12}} {
+		samples := make([]float64, S)
+		for i := range samples {
+			k := r.Hypergeometric(c.N, c.K, c.n)
+			if k < 0 || k > c.K || k > c.n || c.n-k > c.N-c.K {
+				t.Fatalf("N %v, K %v, n %v: got impossible value %v", c.N, c.K, c.n, k)
+			}
+			samples[i] = float64(k)
+		}
+		m, v := meanVariance(samples)
+		N, K, n := float64(c.N), float64(c.K), float64(c.n)
+		want, wantV := n*K/N, n*K/N*(N-K)/N*(N-n)/(N-1)
+		if math.Abs(m-want) > 5*math.Sqrt(wantV/S) {
+			t.Errorf("N %v, K %v, n %v: got mean %v instead of %v", c.N, c.K, c.n, m, want)
+		}
+		if math.Abs(v-wantV) > 0.05*wantV {
+			t.Errorf("N %v, K %v, n %v: got variance %v instead of %v", c.N, c.K, c.n, v, wantV)
+		}
+	}
+}
+
+func TestRand_Simplex(t *testing.T) {
+	const N = 20000
+	r := rand.New(1)
+	for _, n := range []int{1, 2, 5, 17} {
+		means := make([]float64, n)
+		for i := 0; i < N; i++ {
+			p := r.Simplex(n)
+			sum := 0.0
+			for j, v := range p {
+				if v < 0 {
+					t.Fatalf("got negative coordinate in %v", p)
+				}
+				sum += v
+				means[j] += v / N
+			}
+			if math.Abs(sum-1) > 1e-9 {
+				t.Fatalf("got coordinates summing to %v instead of 1: %v", sum, p)
+			}
+		}
+		// marginals are Beta(1, n-1), with variance (n-1)/(n^2 (n+1))
+		sd := math.Sqrt(float64(n-1) / (float64(n*n) * float64(n+1)) / N)
+		for j, m := range means {
+			if math.Abs(m-1/float64(n)) > 5*sd+1e-12 {
+				t.Errorf("n %v: coordinate %v has mean %v instead of %v", n, j, m, 1/float64(n))
+			}
+		}
+	}
+}
+
+func BenchmarkRand_NormFloat64Into(b *testing.B) {
+	for _, n := range []int{tiny, small} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			r := rand.New(1)
+			dst := make([]float64, n)
+			b.SetBytes(int64(8 * n))
+			for i := 0; i < b.N; i++ {
+				r.NormFloat64Into(dst)
+			}
+		})
+	}
+}
+
+func BenchmarkRand_NormFloat64Loop(b *testing.B) {
+	for _, n := range []int{tiny, small} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			r := rand.New(1)
+			dst := make([]float64, n)
+			b.SetBytes(int64(8 * n))
+			for i := 0; i < b.N; i++ {
+				for j := range dst {
+					dst[j] = r.NormFloat64()
+				}
+			}
+		})
+	}
+}
+
+func TestRand_NormFloat64Into(t *testing.T) {
+	const N = 100000
+	r1 := rand.New(1)
+	r2 := rand.New(1)
+	dst := make([]float64, N)
+	r1.NormFloat64Into(dst)
+	for i, x := range dst {
+		if y := r2.NormFloat64(); x != y {
+			t.Fatalf("got %v at %v instead of %v", x, i, y)
+		}
+	}
+	m, v := meanVariance(dst)
+	if math.Abs(m) > 5*math.Sqrt(1.0/N) {
+		t.Errorf("got mean %v instead of 0", m)
+	}
+	if math.Abs(v-1) > 0.05 {
+		t.Errorf("got variance %v instead of 1", v)
+	}
+	tail := 0
+	for _, x := range dst {
+		if math.Abs(x) > 3.6541528853610088 {
+			tail++
+		}
+	}
+	// P(|X| > 3.654) is about 2.58e-4
+	if want := 2.58e-4 * N; math.Abs(float64(tail)-want) > 5*math.Sqrt(want) {
+		t.Errorf("got %v values in the base strip tail instead of ~%v", tail, want)
+	}
+}
+
+func TestRand_SortedFloat64s(t *testing.T) {
+	const (
+		N = 20000
+		n = 9
+	)
+	r := rand.New(1)
+	var means [n]float64
+	for i := 0; i < N; i++ {
+		s := r.SortedFloat64s(n)
+		for j, v := range s {
+			if v < 0 || v >= 1 || (j > 0 && v < s[j-1]) {
+				t.Fatalf("got unsorted or out of range values %v", s)
+			}
+			means[j] += v / N
+		}
+	}
+	// the k-th of n sorted uniforms is distributed as Beta(k, n+1-k)
+	for j, m := range means {
+		k := float64(j + 1)
+		want := k / (n + 1)
+		sd := math.Sqrt(k * (n + 1 - k) / ((n + 1) * (n + 1) * (n + 2)) / N)
+		if math.Abs(m-want) > 5*sd {
+			t.Errorf("value %v has mean %v instead of %v", j, m, want)
+		}
+	}
+}
+
+func TestRand_Stratified(t *testing.T) {
+	r := rand.New(1)
+	for _, n := range []int{0, 1, 2, 7, small, 1 << 20} {
+		s := r.Stratified(n)
+		if len(s) != n {
+			t.Fatalf("got %v values instead of %v", len(s), n)
+		}
+		for i, x := range s {
+			if x < float64(i)/float64(n) || x >= float64(i+1)/float64(n) {
+				t.Fatalf("n %v: value %v is %v, outside of its stratum", n, i, x)
+			}
+		}
+	}
+}
+
+func TestRand_BiasedSimplex(t *testing.T) {
+	const (
+		N = 20000
+		n = 5
+	)
+	r := rand.New(1)
+	prev := math.Inf(1)
+	for _, c := range []float64{0.001, 0.1, 1, 10} {
+		means := make([]float64, n)
+		maxMean := 0.0
+		for i := 0; i < N; i++ {
+			p := r.BiasedSimplex(n, c)
+			sum, max := 0.0, 0.0
+			for j, v := range p {
+				if !(v >= 0) {
+					t.Fatalf("got invalid coordinate in %v", p)
+				}
+				sum += v
+				max = math.Max(max, v)
+				means[j] += v / N
+			}
+			if math.Abs(sum-1) > 1e-9 {
+				t.Fatalf("got coordinates summing to %v instead of 1: %v", sum, p)
+			}
+			maxMean += max / N
+		}
+		for j, m := range means {
+			if math.Abs(m-1.0/n) > 0.02 {
+				t.Errorf("concentration %v: coordinate %v has mean %v instead of %v", c, j, m, 1.0/n)
+			}
+		}
+		if c < 0.01 && maxMean < 0.99 {
+			t.Errorf("concentration %v: got mean dominant component %v", c, maxMean)
+		}
+		if maxMean >= prev {
+			t.Errorf("concentration %v: mean dominant component %v is not smaller than %v for lower concentration", c, maxMean, prev)
+		}
+		prev = maxMean
+	}
+	if prev > 0.5 {
+		t.Errorf("got mean dominant component %v for high concentration", prev)
+	}
+}
+
+func TestRand_TruncatedNormal(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	phi := func(x float64) float64 { return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi) }
+	sf := func(x float64) float64 { return 0.5 * math.Erfc(x/math.Sqrt2) }
+	for _, c := range []struct{ mean, stddev, lo, hi float64 }{
+		{0, 1, -1, 1},
+		{0, 1, -10, 10},
+		{5, 2, 6, 7},
+		{0, 1, 3, math.Inf(1)},
+		{0, 1, 8, 8.5},
+		{1, 3, math.Inf(-1), -20},
+		{0, 1, -0.5, 3},
+	} {
+		samples := make([]float64, N)
+		for i := range samples {
+			x := r.TruncatedNormal(c.mean, c.stddev, c.lo, c.hi)
+			if x < c.lo || x > c.hi {
+				t.Fatalf("got %v outside of [%v, %v]", x, c.lo, c.hi)
+			}
+			samples[i] = x
+		}
+		m, v := meanVariance(samples)
+		alpha, beta := (c.lo-c.mean)/c.stddev, (c.hi-c.mean)/c.stddev
+		want := c.mean + c.stddev*(phi(alpha)-phi(beta))/(sf(alpha)-sf(beta))
+		if math.Abs(m-want) > 5*math.Sqrt(v/N) {
+			t.Errorf("%+v: got mean %v instead of %v", c, m, want)
+		}
+	}
+}
+
+func TestRand_Laplace(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	for _, c := range []struct{ mu, b float64 }{{0, 1}, {-3, 0.5}, {10, 4}} {
+		samples := make([]float64, N)
+		above := 0
+		for i := range samples {
+			samples[i] = r.Laplace(c.mu, c.b)
+			if samples[i] > c.mu {
+				above++
+			}
+		}
+		m, v := meanVariance(samples)
+		wantV := 2 * c.b * c.b
+		if math.Abs(m-c.mu) > 5*math.Sqrt(wantV/N) {
+			t.Errorf("mu %v, b %v: got mean %v instead of %v", c.mu, c.b, m, c.mu)
+		}
+		if math.Abs(v-wantV) > 0.05*wantV {
+			t.Errorf("mu %v, b %v: got variance %v instead of %v", c.mu, c.b, v, wantV)
+		}
+		if math.Abs(float64(above)-N/2) > 5*math.Sqrt(N/4) {
+			t.Errorf("mu %v, b %v: got %v of %v values above mu", c.mu, c.b, above, N)
+		}
+	}
+}
+
+func TestRand_Rayleigh(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	for _, sigma := range []float64{0.1, 1, 7} {
+		samples := make([]float64, N)
+		for i := range samples {
+			samples[i] = r.Rayleigh(sigma)
+			if !(samples[i] > 0) || math.IsInf(samples[i], 0) {
+				t.Fatalf("got %v outside of (0, +Inf)", samples[i])
+			}
+		}
+		m, _ := meanVariance(samples)
+		want, wantV := sigma*math.Sqrt(math.Pi/2), (4-math.Pi)/2*sigma*sigma
+		if math.Abs(m-want) > 5*math.Sqrt(wantV/N) {
+			t.Errorf("sigma %v: got mean %v instead of %v", sigma, m, want)
+		}
+	}
+}
+
+func TestRand_SampleInverseCDF(t *testing.T) {
+	const (
+		N      = 100000
+		lambda = 2.5
+	)
+	r := rand.New(1)
+	for _, sample := range []func(func(float64) float64) float64{r.SampleInverseCDF, r.SampleInverseCDFPos} {
+		samples := make([]float64, N)
+		for i := range samples {
+			// exponential distribution via the inverse CDF of 1 - u, which is uniform too
+			samples[i] = sample(func(u float64) float64 { return -math.Log1p(-u) / lambda })
+			if !(samples[i] >= 0) || math.IsInf(samples[i], 0) {
+				t.Fatalf("got %v outside of [0, +Inf)", samples[i])
+			}
+		}
+		m, v := meanVariance(samples)
+		want, wantV := 1/lambda, 1/(lambda*lambda)
+		if math.Abs(m-want) > 5*math.Sqrt(wantV/N) {
+			t.Errorf("got mean %v instead of %v", m, want)
+		}
+		if math.Abs(v-wantV) > 0.05*wantV {
+			t.Errorf("got variance %v instead of %v", v, wantV)
+		}
+	}
+	if x := r.SampleInverseCDFPos(math.Log); math.IsInf(x, 0) || x >= 0 {
+		t.Errorf("got %v from log of a value in (0, 1)", x)
+	}
+}
+
+func TestRand_PowerLawInt(t *testing.T) {
+	const (
+		N    = 1000000
+		xmin = 10
+		xmax = 100
+	)
+	r := rand.New(1)
+	for _, exponent := range []float64{1.5, 2, 2.5, 3.5} {
+		var counts [xmax]int
+		for i := 0; i < N; i++ {
+			x := r.PowerLawInt(exponent, xmin)
+			if x < xmin {
+				t.Fatalf("got %v less than %v", x, xmin)
+			}
+			if x < xmax {
+				counts[x]++
+			}
+		}
+		// least squares fit of log(count) against log(x)
+		var sx, sy, sxx, sxy, n float64
+		for x := xmin; x < xmax; x++ {
+			lx, ly := math.Log(float64(x)), math.Log(float64(counts[x]))
+			sx, sy, sxx, sxy, n = sx+lx, sy+ly, sxx+lx*lx, sxy+lx*ly, n+1
+		}
+		slope := (n*sxy - sx*sy) / (n*sxx - sx*sx)
+		if math.Abs(slope+exponent) > 0.1*exponent {
+			t.Errorf("exponent %v: got log-log slope %v", exponent, slope)
+		}
+	}
+}
+
+func TestRand_RecencyBiasedIndex(t *testing.T) {
+	const (
+		N = 200000
+		n = 50
+	)
+	r := rand.New(1)
+	for _, halfLife := range []float64{1e-9, 0.5, 3, 10, 1e9} {
+		var counts [n]int
+		for i := 0; i < N; i++ {
+			k := r.RecencyBiasedIndex(n, halfLife)
+			if k < 0 || k >= n {
+				t.Fatalf("got %v outside of [0, %v)", k, n)
+			}
+			counts[k]++
+		}
+		if halfLife < 1e6 && counts[0] <= counts[n-1] {
+			t.Errorf("half-life %v: index 0 chosen %v times, index %v chosen %v times", halfLife, counts[0], n-1, counts[n-1])
+		}
+		// the probability of index i relative to index 0 is 2^(-i/halfLife)
+		h := int(math.Ceil(halfLife))
+		if h < n && counts[h] > 1000 {
+			want := float64(counts[0]) * math.Exp2(-float64(h)/halfLife)
+			if math.Abs(float64(counts[h])-want) > 5*math.Sqrt(want)+0.05*want {
+				t.Errorf("half-life %v: index %v chosen %v times instead of ~%v", halfLife, h, counts[h], want)
+			}
+		}
+	}
+}
+
+func TestRand_Kumaraswamy(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	for _, c := range []struct{ a, b float64 }{{1, 1}, {0.5, 0.5}, {2, 5}, {5, 2}, {0.3, 3}} {
+		samples := make([]float64, N)
+		for i := range samples {
+			samples[i] = r.Kumaraswamy(c.a, c.b)
+			if !(samples[i] > 0 && samples[i] < 1) {
+				t.Fatalf("got %v outside of (0, 1)", samples[i])
+			}
+		}
+		m, _ := meanVariance(samples)
+		// E[X^k] = b B(1 + k/a, b)
+		moment := func(k float64) float64 {
+			l1, _ := math.Lgamma(1 + k/c.a)
+			l2, _ := math.Lgamma(c.b)
+			l3, _ := math.Lgamma(1 + k/c.a + c.b)
+			return c.b * math.Exp(l1+l2-l3)
+		}
+		want := moment(1)
+		wantV := moment(2) - want*want
+		if math.Abs(m-want) > 5*math.Sqrt(wantV/N) {
+			t.Errorf("a %v, b %v: got mean %v instead of %v", c.a, c.b, m, want)
+		}
+	}
+}
+
+func TestRand_Kumaraswamy_Extreme(t *testing.T) {
+	r := rand.New(1)
+	for _, c := range []struct{ a, b float64 }{
+		{1, 0.001}, {0.001, 1}, {1000, 1}, {1, 1000}, {0.001, 0.001}, {1e6, 1e-6}, {1e-6, 1e6}, {math.Inf(1), math.Inf(1)},
+	} {
+		for i := 0; i < 10000; i++ {
+			if x := r.Kumaraswamy(c.a, c.b); !(x > 0 && x < 1) {
+				t.Fatalf("a %v, b %v: got %v outside of (0, 1)", c.a, c.b, x)
+			}
+		}
+	}
+}
+
+func TestRand_CoinFlips(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	for _, k := range []int{0, 1, 7, 64, 65, 1000} {
+		samples := make([]float64, N)
+		for i := range samples {
+			n := r.CoinFlips(k)
+			if n < 0 || n > k {
+				t.Fatalf("got %v heads outside of [0, %v]", n, k)
+			}
+			samples[i] = float64(n)
+		}
+		m, v := meanVariance(samples)
+		want, wantV := float64(k)/2, float64(k)/4
+		if math.Abs(m-want) > 5*math.Sqrt(wantV/N) {
+			t.Errorf("k %v: got mean %v instead of %v", k, m, want)
+		}
+		if math.Abs(v-wantV) > 0.05*wantV {
+			t.Errorf("k %v: got variance %v instead of %v", k, v, wantV)
+		}
+	}
+}
+
+// ksStatistic returns the two-sample Kolmogorov-Smirnov statistic of a and b, sorting them in place.
+func ksStatistic(a, b []float64) float64 {
+	sort.Float64s(a)
+	sort.Float64s(b)
+	d := 0.0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if a[i] <= b[j] {
+			i++
+		} else {
+			j++
+		}
+		d = math.Max(d, math.Abs(float64(i)/float64(len(a))-float64(j)/float64(len(b))))
+	}
+	return d
+}
+
+func TestRand_MaxOfUniforms(t *testing.T) {
+	const N = 20000
+	r := rand.New(1)
+	for _, k := range []int{1, 2, 5, 30} {
+		fast := make([]float64, N)
+		slow := make([]float64, N)
+		for i := range fast {
+			fast[i] = r.MaxOfUniforms(k)
+			if fast[i] < 0 || fast[i] >= 1 {
+				t.Fatalf("got %v outside of [0, 1)", fast[i])
+			}
+			for j := 0; j < k; j++ {
+				slow[i] = math.Max(slow[i], r.Float64())
+			}
+		}
+		// critical value for significance level 0.001
+		if d, c := ksStatistic(fast, slow), 1.95*math.Sqrt(2.0/N); d > c {
+			t.Errorf("k %v: got Kolmogorov-Smirnov statistic %v, more than %v", k, d, c)
+		}
+	}
+}
+
+func TestRand_MinOfUniforms(t *testing.T) {
+	const N = 20000
+	r := rand.New(1)
+	for _, k := range []int{1, 2, 5, 30} {
+		fast := make([]float64, N)
+		slow := make([]float64, N)
+		for i := range fast {
+			fast[i] = r.MinOfUniforms(k)
+			if fast[i] < 0 || fast[i] >= 1 {
+				t.Fatalf("got %v outside of [0, 1)", fast[i])
+			}
+			slow[i] = 1
+			for j := 0; j < k; j++ {
+				slow[i] = math.Min(slow[i], r.Float64())
+			}
+		}
+		// critical value for significance level 0.001
+		if d, c := ksStatistic(fast, slow), 1.95*math.Sqrt(2.0/N); d > c {
+			t.Errorf("k %v: got Kolmogorov-Smirnov statistic %v, more than %v", k, d, c)
+		}
+	}
+}
+
+func TestRand_Beta4(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	for _, c := range []struct{ alpha, beta, lo, hi float64 }{
+		{1, 1, 0, 1},
+		{0.5, 0.5, -1, 1},
+		{2, 5, 10, 20},
+		{5, 2, -3, -2},
+		{0.1, 3, 0, 100},
+		{50, 50, 1, 1.5},
+	} {
+		samples := make([]float64, N)
+		for i := range samples {
+			samples[i] = r.Beta4(c.alpha, c.beta, c.lo, c.hi)
+			if !(samples[i] > c.lo && samples[i] < c.hi) {
+				t.Fatalf("got %v outside of (%v, %v)", samples[i], c.lo, c.hi)
+			}
+		}
+		m, v := meanVariance(samples)
+		s := c.alpha + c.beta
+		w := c.hi - c.lo
+		want := c.lo + w*c.alpha/s
+		wantV := w * w * c.alpha * c.beta / (s * s * (s + 1))
+		if math.Abs(m-want) > 5*math.Sqrt(wantV/N) {
+			t.Errorf("%+v: got mean %v instead of %v", c, m, want)
+		}
+		if math.Abs(v-wantV) > 0.05*wantV {
+			t.Errorf("%+v: got variance %v instead of %v", c, v, wantV)
+		}
+	}
+}
+
+func TestRand_Gumbel(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	for _, c := range []struct{ mu, beta float64 }{{0, 1}, {-3, 0.5}, {10, 4}} {
+		samples := make([]float64, N)
+		for i := range samples {
+			samples[i] = r.Gumbel(c.mu, c.beta)
+			if math.IsInf(samples[i], 0) || math.IsNaN(samples[i]) {
+				t.Fatalf("got %v", samples[i])
+			}
+		}
+		m, v := meanVariance(samples)
+		want, wantV := c.mu+c.beta*0.5772156649015329, math.Pi*math.Pi/6*c.beta*c.beta
+		if math.Abs(m-want) > 5*math.Sqrt(wantV/N) {
+			t.Errorf("mu %v, beta %v: got mean %v instead of %v", c.mu, c.beta, m, want)
+		}
+		if math.Abs(v-wantV) > 0.05*wantV {
+			t.Errorf("mu %v, beta %v: got variance %v instead of %v", c.mu, c.beta, v, wantV)
+		}
+	}
+}
+
+func TestRand_Gumbel_Max(t *testing.T) {
+	const N = 100000
+	logits := []float64{1, 2, 0.5, -1, 3}
+	sum := 0.0
+	for _, l := range logits {
+		sum += math.Exp(l)
+	}
+	r := rand.New(1)
+	counts := make([]int, len(logits))
+	for i := 0; i < N; i++ {
+		best, max := 0, math.Inf(-1)
+		for j, l := range logits {
+			if x := l + r.Gumbel(0, 1); x > max {
+				best, max = j, x
+			}
+		}
+		counts[best]++
+	}
+	for i, c := range counts {
+		p := math.Exp(logits[i]) / sum
+		if want := N * p; math.Abs(float64(c)-want) > 5*math.Sqrt(N*p*(1-p)) {
+			t.Errorf("logit %v won %v times instead of ~%v", i, c, want)
+		}
+	}
+}
+
+func TestRand_RejectionSample(t *testing.T) {
+	const N = 100000
+	// standard normal truncated to [a, b)
+	a, b := -1.0, 2.0
+	phi := func(x float64) float64 { return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi) }
+	z := (math.Erf(b/math.Sqrt2) - math.Erf(a/math.Sqrt2)) / 2
+	want := (phi(a) - phi(b)) / z
+	wantV := 1 + (a*phi(a)-b*phi(b))/z - want*want
+	r := rand.New(1)
+	samples := make([]float64, N)
+	for i := range samples {
+		samples[i] = r.RejectionSample(phi, a, b, phi(0))
+		if samples[i] < a || samples[i] >= b {
+			t.Fatalf("got %v outside [%v, %v)", samples[i], a, b)
+		}
+	}
+	m, v := meanVariance(samples)
+	if math.Abs(m-want) > 5*math.Sqrt(wantV/N) {
+		t.Errorf("got mean %v instead of %v", m, want)
+	}
+	if math.Abs(v-wantV) > 0.05*wantV {
+		t.Errorf("got variance %v instead of %v", v, wantV)
+	}
+}
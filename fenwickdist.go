@@ -0,0 +1,116 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "math"
+
+// A FenwickDist samples indices with probabilities proportional to weights that can be
+// updated between draws. Both drawing and updating take O(log n) time, using a binary indexed tree
+// of weight sums.
+type FenwickDist struct {
+	weights  []float64
+	tree     []float64
+	step     int // largest power of 2 not exceeding len(weights)
+	positive int // number of positive weights
+}
+
+// NewFenwickDist returns a distribution where index i has probability proportional to weights[i].
+// It panics if any weight is negative or not finite.
+func NewFenwickDist(weights []float64) *FenwickDist {
+	d := &FenwickDist{
+		weights: make([]float64, len(weights)),
+		tree:    make([]float64, len(weights)+1),
+		step:    1,
+	}
+	for d.step*2 <= len(weights) {
+		d.step *= 2
+	}
+	for i, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			panic("invalid argument to NewFenwickDist")
+		}
+		d.weights[i] = w
+		if w > 0 {
+			d.positive++
+		}
+	}
+	d.build()
+	return d
+}
+
+// build computes the tree of weight sums from scratch, discarding the rounding errors
+// accumulated by updates.
+func (d *FenwickDist) build() {
+	for i := range d.tree {
+		d.tree[i] = 0
+	}
+	for i, w := range d.weights {
+		d.tree[i+1] += w
+		if j := i + 1 + (i+1)&-(i+1); j <= len(d.weights) {
+			d.tree[j] += d.tree[i+1]
+		}
+	}
+}
+
+// Update sets the weight of index i to w. It panics if i is out of range, or w is negative or not finite.
+func (d *FenwickDist) Update(i int, w float64) {
+	if i < 0 || i >= len(d.weights) || !(w >= 0) || math.IsInf(w, 1) {
+		panic("invalid argument to Update")
+	}
+	if d.weights[i] > 0 {
+		d.positive--
+	}
+	if w > 0 {
+		d.positive++
+	}
+	delta := w - d.weights[i]
+	d.weights[i] = w
+	for i++; i < len(d.tree); i += i & -i {
+		d.tree[i] += delta
+	}
+}
+
+// Total returns the sum of all weights. After updates, it can differ from the exact sum
+// by rounding errors, but it is 0 when all weights are zero.
+func (d *FenwickDist) Total() float64 {
+	if d.positive == 0 {
+		return 0
+	}
+	sum := 0.0
+	for i := len(d.weights); i > 0; i -= i & -i {
+		sum += d.tree[i]
+	}
+	return sum
+}
+
+// Draw returns an index i chosen with probability proportional to its weight.
+// Draw can recompute the internal sums, so like Update, it must not be called concurrently
+// with other methods. It panics if all weights are zero.
+func (d *FenwickDist) Draw(r *Rand) int {
+	if d.positive == 0 {
+		panic("FenwickDist has no positive weights")
+	}
+	total := d.Total()
+	for {
+		// find the largest pos such that the sum of weights [0, pos) is <= u
+		u := r.Float64() * total
+		pos := 0
+		for step := d.step; step > 0; step /= 2 {
+			if pos+step < len(d.tree) && d.tree[pos+step] <= u {
+				pos += step
+				u -= d.tree[pos]
+			}
+		}
+		if pos < len(d.weights) && d.weights[pos] > 0 {
+			return pos
+		}
+		// rounding errors in the updated sums can land on a zero weight or past the end,
+		// and can dwarf small weights, so rebuild the sums before retrying
+		d.build()
+		total = d.Total()
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"testing"
+)
+
+func checkFenwickDist(t *testing.T, r *rand.Rand, d *rand.FenwickDist, weights []float64) {
+	t.Helper()
+	const N = 100000
+	sum := 0.0
+	for _, w := range weights {
+		sum += w
+	}
+	counts := make([]int, len(weights))
+	for i := 0; i < N; i++ {
+		counts[d.Draw(r)]++
+	}
+	for i, c := range counts {
+		p := weights[i] / sum
+		if want := N * p; math.Abs(float64(c)-want) > 5*math.Sqrt(N*p*(1-p)) {
+			t.Errorf("weights %v: index %v drawn %v times instead of ~%v", weights, i, c, want)
+		}
+	}
+}
+
+func TestFenwickDist(t *testing.T) {
+	r := rand.New(1)
+	weights := []float64{1, 0, 2, 3, 0.5, 0, 7}
+	d := rand.NewFenwickDist(weights)
+	checkFenwickDist(t, r, d, weights)
+	for _, u := range []struct {
+		i int
+		w float64
+	}{{6, 0}, {1, 4}, {0, 10}, {3, 0}, {5, 1}} {
+		weights[u.i] = u.w
+		d.Update(u.i, u.w)
+		want := 0.0
+		for _, w := range weights {
+			want += w
+		}
+		if total := d.Total(); math.Abs(total-want) > 1e-9 {
+			t.Errorf("got total %v instead of %v", total, want)
+		}
+		checkFenwickDist(t, r, d, weights)
+	}
+}
+
+func TestFenwickDist_Sizes(t *testing.T) {
+	r := rand.New(1)
+	for n := 1; n <= 17; n++ {
+		weights := make([]float64, n)
+		weights[n-1] = 1
+		d := rand.NewFenwickDist(weights)
+		for i := 0; i < 100; i++ {
+			if j := d.Draw(r); j != n-1 {
+				t.Fatalf("n %v: got index %v with zero weight", n, j)
+			}
+		}
+	}
+}
+
+func TestFenwickDist_ZeroedByUpdates(t *testing.T) {
+	r := rand.New(1)
+	d := rand.NewFenwickDist([]float64{0.1, 0.2, 0.3})
+	for i := 0; i < 3; i++ {
+		d.Update(i, 0)
+	}
+	if total := d.Total(); total != 0 {
+		t.Errorf("got total %v after zeroing all weights", total)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("no panic when drawing with all weights zeroed by updates")
+			}
+		}()
+		d.Draw(r)
+	}()
+	// a weight smaller than the rounding errors left by the updates
+	d.Update(1, 1e-300)
+	for i := 0; i < 100; i++ {
+		if j := d.Draw(r); j != 1 {
+			t.Fatalf("got index %v with zero weight", j)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+const (
+	fillMaxLen   = 8
+	fillMaxDepth = 8
+)
+
+// FillStruct populates the exported fields of the struct pointed to by v with pseudo-random values:
+// booleans, integers, floats, complex numbers, non-empty alphanumeric strings, and recursively
+// nested structs, arrays, slices of 1 to 8 elements and pointers. Pointers nested more than 8 levels
+// deep are left nil, so recursive types are supported. Unexported fields are left unchanged.
+// FillStruct returns an error if v is not a non-nil pointer to a struct, or if a field (or element)
+// has an unsupported kind, such as a channel, function, interface or map.
+func FillStruct(r *Rand, v interface{}) error {
+	p := reflect.ValueOf(v)
+	if p.Kind() != reflect.Ptr || p.IsNil() || p.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("rand: FillStruct: %T is not a non-nil pointer to a struct", v)
+	}
+	if err := r.fill(p.Elem(), 0); err != nil {
+		return fmt.Errorf("rand: FillStruct: %w", err)
+	}
+	return nil
+}
+
+func (r *Rand) fill(v reflect.Value, depth int) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Uint32n(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(r.Uint64()) >> (64 - v.Type().Bits()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(r.Uint64() >> (64 - v.Type().Bits()))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(r.fillFloat())
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(r.fillFloat(), r.fillFloat()))
+	case reflect.String:
+		v.SetString(r.fillString())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := r.fill(v.Index(i), depth); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		s := reflect.MakeSlice(v.Type(), 1+int(r.Uint32n(fillMaxLen)), 1+fillMaxLen)
+		for i := 0; i < s.Len(); i++ {
+			if err := r.fill(s.Index(i), depth); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	case reflect.Ptr:
+		if depth >= fillMaxDepth {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		p := reflect.New(v.Type().Elem())
+		if err := r.fill(p.Elem(), depth+1); err != nil {
+			return err
+		}
+		v.Set(p)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath != "" { // unexported
+				continue
+			}
+			if err := r.fill(v.Field(i), depth); err != nil {
+				return fmt.Errorf("field %v: %w", t.Field(i).Name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported kind %v", v.Kind())
+	}
+	return nil
+}
+
+// fillFloat returns a finite float64 with a pseudo-random sign and magnitude in [0, 1000).
+func (r *Rand) fillFloat() float64 {
+	return math.Copysign(r.Float64()*1000, float64(int64(r.Uint32n(2))*2-1))
+}
+
+// fillString returns a non-empty alphanumeric string of at most 16 bytes.
+func (r *Rand) fillString() string {
+	var b strings.Builder
+	r.randomWord(&b, alnum, 1+r.SliceLen(15))
+	return b.String()
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+type fillInner struct {
+	Name  string
+	Score float32
+	Tags  []string
+}
+
+type fillOuter struct {
+	ID      int64
+	Count   uint8
+	Ratio   float64
+	Enabled bool
+	Z       complex128
+	Inner   fillInner
+	Ptr     *fillInner
+	List    []fillInner
+	Arr     [3]int16
+	Next    *fillOuter
+	private int
+}
+
+func TestFillStruct(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		var v fillOuter
+		if err := rand.FillStruct(r, &v); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v.private != 0 {
+			t.Fatalf("unexported field was filled")
+		}
+		if v.Inner.Name == "" || len(v.Inner.Tags) == 0 || v.Ptr == nil || v.Ptr.Name == "" || len(v.List) == 0 || v.Next == nil {
+			t.Fatalf("nested values are not filled: %+v", v)
+		}
+		depth := 0
+		for p := &v; p.Next != nil; p = p.Next {
+			depth++
+		}
+		if depth > 8 {
+			t.Fatalf("got recursion depth %v", depth)
+		}
+	})
+}
+
+func TestFillStruct_NonZero(t *testing.T) {
+	r := rand.New(1)
+	zeros := map[string]int{}
+	for i := 0; i < 100; i++ {
+		var v fillOuter
+		if err := rand.FillStruct(r, &v); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for name, zero := range map[string]bool{
+			"ID":      v.ID == 0,
+			"Count":   v.Count == 0,
+			"Ratio":   v.Ratio == 0,
+			"Enabled": !v.Enabled,
+			"Z":       v.Z == 0,
+			"Score":   v.Inner.Score == 0,
+			"Arr":     v.Arr == [3]int16{},
+		} {
+			if zero {
+				zeros[name]++
+			}
+		}
+	}
+	for name, n := range zeros {
+		if n > 5 && !(name == "Enabled" && n < 70) {
+			t.Errorf("field %v was zero %v times out of 100", name, n)
+		}
+	}
+}
+
+func TestFillStruct_Unsupported(t *testing.T) {
+	r := rand.New(1)
+	x := 0
+	for _, v := range []interface{}{
+		nil,
+		x,
+		&x,
+		&struct{ C chan int }{},
+		&struct{ F func() }{},
+		&struct{ M map[string]int }{},
+		&struct{ I interface{} }{},
+	} {
+		if err := rand.FillStruct(r, v); err == nil {
+			t.Errorf("got no error for %T", v)
+		}
+	}
+}
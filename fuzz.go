@@ -0,0 +1,626 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"image/color"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	sliceLenBias = 0.1
+
+	alnum       = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	alnumHyphen = alnum + "-"
+	letters     = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	emailLocal  = alnum + "!#$%&'*+-/=?^_`{|}~"
+	pathSafe    = alnum + "-._"
+	argSpecial  = " \t'\"\\$*;|&"
+	sqlSpecial  = " '\"\\%_;\n"
+)
+
+// SliceLen returns, as an int, a pseudo-random length in the closed interval [0, maxLen],
+// biased towards small values. It is equivalent to SliceLenBiased(maxLen, 0.1). It panics if maxLen < 0.
+func (r *Rand) SliceLen(maxLen int) int {
+	return r.SliceLenBiased(maxLen, sliceLenBias)
+}
+
+// SliceLenBiased returns, as an int, a pseudo-random length in the closed interval [0, maxLen]
+// drawn from a geometric distribution truncated to maxLen: length k is chosen with probability
+// proportional to (1 - smallBias)^k. smallBias of 0 results in a uniform distribution, and values
+// closer to 1 make small lengths more likely. It panics if maxLen < 0 or smallBias is outside of [0, 1).
+func (r *Rand) SliceLenBiased(maxLen int, smallBias float64) int {
+	if maxLen < 0 || !(smallBias >= 0 && smallBias < 1) {
+		panic("invalid argument to SliceLenBiased")
+	}
+	if smallBias == 0 {
+		return int(r.Uint64n(uint64(maxLen) + 1))
+	}
+	return r.truncatedGeometric(math.Log1p(-smallBias), maxLen)
+}
+
+// truncatedGeometric returns k in the closed interval [0, maxLen] with probability
+// proportional to q^k, where lq = log(q) < 0.
+func (r *Rand) truncatedGeometric(lq float64, maxLen int) int {
+	// inversion of the truncated geometric CDF, P(K <= k) = (1 - q^(k+1)) / (1 - q^(maxLen+1))
+	tail := -math.Expm1(lq * (float64(maxLen) + 1))
+	k := math.Floor(math.Log1p(-r.Float64()*tail) / lq)
+	if !(k < float64(maxLen)) {
+		return maxLen
+	}
+	return int(k)
+}
+
+// LogUniformDuration returns a pseudo-random duration in the half-open interval [min, max),
+// distributed uniformly in log-space, so that all orders of magnitude between min and max
+// are equally likely. It panics if min <= 0 or min >= max.
+func (r *Rand) LogUniformDuration(min, max time.Duration) time.Duration {
+	if min <= 0 || min >= max {
+		panic("invalid argument to LogUniformDuration")
+	}
+	lo := math.Log(float64(min))
+	f := math.Exp(lo + r.Float64()*(math.Log(float64(max))-lo))
+	if f >= float64(max) {
+		return max - 1
+	}
+	if d := time.Duration(f); d > min {
+		return d
+	}
+	return min
+}
+
+// RandomLess returns a less function that induces a pseudo-random strict total order
+// over the integers in the half-open interval [0, n). Unlike a comparator that flips
+// a coin on every call, the returned function is consistent and transitive, which makes
+// it suitable for testing sorting code. It panics if n < 0.
+func (r *Rand) RandomLess(n int) func(i, j int) bool {
+	if n < 0 {
+		panic("invalid argument to RandomLess")
+	}
+	rank := r.Perm(n)
+	return func(i, j int) bool {
+		return rank[i] < rank[j]
+	}
+}
+
+// Version returns a pseudo-random version string that conforms to the Semantic Versioning 2.0.0
+// grammar, prefixed with "v", such as "v1.2.3", "v0.10.0-rc.1" or "v1.2.3-beta.4+build.5".
+// Pre-release and build metadata are each present with probability 1/2.
+func (r *Rand) Version() string {
+	var b strings.Builder
+	b.WriteByte('v')
+	for i := 0; i < 3; i++ {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(strconv.Itoa(r.SliceLen(math.MaxInt32)))
+	}
+	if r.Uint32n(2) == 0 {
+		b.WriteByte('-')
+		r.versionIdents(&b, true)
+	}
+	if r.Uint32n(2) == 0 {
+		b.WriteByte('+')
+		r.versionIdents(&b, false)
+	}
+	return b.String()
+}
+
+// versionIdents writes dot-separated identifiers of pre-release version or build metadata.
+func (r *Rand) versionIdents(b *strings.Builder, pre bool) {
+	n := 1 + r.SliceLen(3)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		if r.Uint32n(2) == 0 {
+			// numeric identifiers of pre-release version must not have leading zeros
+			b.WriteString(strconv.Itoa(r.SliceLen(math.MaxInt32)))
+			continue
+		}
+		l := 1 + r.SliceLen(15)
+		digits := true
+		for j := 0; j < l; j++ {
+			c := alnumHyphen[r.Uint32n(uint32(len(alnumHyphen)))]
+			digits = digits && c >= '0' && c <= '9'
+			b.WriteByte(c)
+		}
+		if pre && digits {
+			b.WriteByte('-')
+		}
+	}
+}
+
+// RaggedInts returns rows slices, each of a pseudo-random length in the closed interval
+// [0, maxCols] and filled with pseudo-random values in the half-open interval [0, maxVal).
+// It panics if rows < 0, maxCols < 0 or maxVal <= 0.
+func (r *Rand) RaggedInts(rows, maxCols, maxVal int) [][]int {
+	if rows < 0 || maxCols < 0 || maxVal <= 0 {
+		panic("invalid argument to RaggedInts")
+	}
+	s := make([][]int, rows)
+	for i := range s {
+		s[i] = make([]int, r.Uint64n(uint64(maxCols)+1))
+		for j := range s[i] {
+			s[i][j] = r.Intn(maxVal)
+		}
+	}
+	return s
+}
+
+// UTF8Bytes returns a valid UTF-8 encoded byte slice of at most maxBytes bytes, made of
+// pseudo-random runes. Encoded lengths of 1 to 4 bytes are equally likely for every rune,
+// and runes are appended until the next one does not fit, so the result is at least
+// maxBytes-3 bytes long. It panics if maxBytes < 0.
+func (r *Rand) UTF8Bytes(maxBytes int) []byte {
+	if maxBytes < 0 {
+		panic("invalid argument to UTF8Bytes")
+	}
+	p := make([]byte, 0, maxBytes)
+	var buf [utf8.UTFMax]byte
+	for {
+		n := utf8.EncodeRune(buf[:], r.utf8Rune())
+		if len(p)+n > maxBytes {
+			return p
+		}
+		p = append(p, buf[:n]...)
+	}
+}
+
+// utf8Rune returns a valid rune with a pseudo-random encoded length.
+func (r *Rand) utf8Rune() rune {
+	switch r.Uint32n(4) {
+	case 0:
+		return rune(r.Uint32n(0x80))
+	case 1:
+		return rune(0x80 + r.Uint32n(0x800-0x80))
+	case 2:
+		// skip surrogate halves [0xD800, 0xE000)
+		c := rune(0x800 + r.Uint32n(0x10000-0x800-0x800))
+		if c >= 0xD800 {
+			c += 0x800
+		}
+		return c
+	default:
+		return rune(0x10000 + r.Uint32n(utf8.MaxRune+1-0x10000))
+	}
+}
+
+// Date returns a pseudo-random valid date at midnight UTC, with the year in the closed interval
+// [minYear, maxYear]. All days in the interval are equally likely, so leap days are generated
+// with their natural frequency. It panics if minYear > maxYear.
+func (r *Rand) Date(minYear, maxYear int) time.Time {
+	if minYear > maxYear {
+		panic("invalid argument to Date")
+	}
+	start := time.Date(minYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(maxYear+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	// end.Sub(start) would saturate for ranges longer than about 292 years
+	days := uint64((end.Unix() - start.Unix()) / (24 * 60 * 60))
+	return start.AddDate(0, 0, int(r.Uint64n(days)))
+}
+
+// bernoulliIndices calls f, in ascending order, with each index in [0, n) independently chosen
+// with probability p, skipping over the gaps between them which are geometrically distributed.
+func (r *Rand) bernoulliIndices(n int, p float64, f func(i int)) {
+	if !(p > 0) {
+		return
+	}
+	lq := math.Log1p(-p)
+	for i := -1; ; {
+		gap := math.Floor(math.Log(r.float64Pos()) / lq)
+		if !(gap < float64(n-i-1)) {
+			return
+		}
+		i += int(gap) + 1
+		f(i)
+	}
+}
+
+// RGBA returns a pseudo-random opaque color.
+func (r *Rand) RGBA() color.RGBA {
+	v := r.Uint32()
+	return color.RGBA{R: uint8(v), G: uint8(v >> 8), B: uint8(v >> 16), A: 0xff}
+}
+
+// RGBATransparent returns a pseudo-random color with a pseudo-random alpha channel. Since
+// color.RGBA holds alpha-premultiplied values, the red, green and blue channels never exceed alpha.
+func (r *Rand) RGBATransparent() color.RGBA {
+	v := r.Uint32()
+	c := color.RGBA{R: uint8(v), G: uint8(v >> 8), B: uint8(v >> 16), A: uint8(v >> 24)}
+	c.R = uint8(uint32(c.R) * uint32(c.A) / 0xff)
+	c.G = uint8(uint32(c.G) * uint32(c.A) / 0xff)
+	c.B = uint8(uint32(c.B) * uint32(c.A) / 0xff)
+	return c
+}
+
+// Email returns a pseudo-random email address of the form local@domain.tld. The local part
+// is a dot-separated sequence of atoms, and the domain is a sequence of hostname labels
+// followed by an alphabetic top-level domain, so the result is valid per RFC 5322 and RFC 1035.
+func (r *Rand) Email() string {
+	var b strings.Builder
+	n := 1 + r.SliceLen(2)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		r.randomWord(&b, emailLocal, 1+r.SliceLen(15))
+	}
+	b.WriteByte('@')
+	r.domainName(&b)
+	return b.String()
+}
+
+// domainName writes a sequence of hostname labels followed by an alphabetic top-level domain.
+func (r *Rand) domainName(b *strings.Builder) {
+	n := 1 + r.SliceLen(2)
+	for i := 0; i < n; i++ {
+		r.hostLabel(b, 1+r.SliceLen(15))
+		b.WriteByte('.')
+	}
+	r.randomWord(b, letters, 2+r.SliceLen(4))
+}
+
+// hostLabel writes a hostname label of n letters, digits and hyphens, not starting or ending with a hyphen.
+func (r *Rand) hostLabel(b *strings.Builder, n int) {
+	b.WriteByte(alnum[r.Uint32n(uint32(len(alnum)))])
+	if n > 1 {
+		r.randomWord(b, alnumHyphen, n-2)
+		b.WriteByte(alnum[r.Uint32n(uint32(len(alnum)))])
+	}
+}
+
+// Hostname returns a pseudo-random hostname valid per RFC 1123: 1 to 4 dot-separated labels
+// of 1 to 63 letters, digits and hyphens, with lengths biased towards small values, where no
+// label starts or ends with a hyphen and the total length does not exceed 253.
+func (r *Rand) Hostname() string {
+	var b strings.Builder
+	n := 1 + r.SliceLen(3)
+	for i := 0; i < n; i++ {
+		l := 1 + r.SliceLen(62)
+		if i > 0 {
+			if b.Len()+1+l > 253 {
+				break
+			}
+			b.WriteByte('.')
+		}
+		r.hostLabel(&b, l)
+	}
+	return b.String()
+}
+
+// InvalidEmail returns a pseudo-random near-miss email address: a valid address from Email
+// with a single defect, such as a missing or doubled '@', an empty or misplaced dot,
+// a hyphen at the edge of a domain label, whitespace, or a domain reduced to a single label.
+func (r *Rand) InvalidEmail() string {
+	e := r.Email()
+	at := strings.IndexByte(e, '@')
+	local, domain := e[:at], e[at+1:]
+	switch r.Uint32n(9) {
+	case 0:
+		return local + domain
+	case 1:
+		return local + "@@" + domain
+	case 2:
+		return "." + e
+	case 3:
+		return local + ".@" + domain
+	case 4:
+		return local + "@" + strings.Replace(domain, ".", "..", 1)
+	case 5:
+		return local + "@-" + domain
+	case 6:
+		i := 1 + int(r.Uint64n(uint64(len(e)-1)))
+		return e[:i] + " " + e[i:]
+	case 7:
+		return "@" + domain
+	default:
+		return local + "@" + domain[:strings.IndexByte(domain, '.')]
+	}
+}
+
+// randomWord writes n pseudo-random bytes from chars.
+func (r *Rand) randomWord(b *strings.Builder, chars string, n int) {
+	for i := 0; i < n; i++ {
+		b.WriteByte(chars[r.Uint32n(uint32(len(chars)))])
+	}
+}
+
+// FilePath returns a pseudo-random slash-separated file path of depth (number of segments)
+// in the closed interval [1, maxDepth], starting with "/" if absolute is true. Segments are
+// 1 to 16 bytes long, made of ASCII letters, digits, '-', '.' and '_', and are never "." or "..",
+// so the path is safe to use on common file systems and is already clean in the sense of path.Clean.
+// It panics if maxDepth < 1.
+func (r *Rand) FilePath(maxDepth int, absolute bool) string {
+	if maxDepth < 1 {
+		panic("invalid argument to FilePath")
+	}
+	var b strings.Builder
+	depth := 1 + int(r.Uint64n(uint64(maxDepth)))
+	for i := 0; i < depth; i++ {
+		if i > 0 || absolute {
+			b.WriteByte('/')
+		}
+		start := b.Len()
+		r.randomWord(&b, pathSafe, 1+int(r.Uint32n(16)))
+		if seg := b.String()[start:]; seg == "." || seg == ".." {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// trickyFloat64s are the edge cases returned by TrickyFloat64, in addition to powers of two.
+var trickyFloat64s = [...]float64{
+	0,
+	math.Copysign(0, -1),
+	math.SmallestNonzeroFloat64,
+	-math.SmallestNonzeroFloat64,
+	0x1p-1022, // smallest normal
+	math.MaxFloat64,
+	-math.MaxFloat64,
+	1,
+	-1,
+	math.NaN(),
+	math.Inf(1),
+	math.Inf(-1),
+}
+
+// TrickyFloat64 returns a pseudo-random float64 that is, with probability 1/2, an edge case
+// likely to trigger numeric bugs: a signed zero, the smallest subnormal or normal, the largest
+// finite value, ±1, a power of two with a pseudo-random sign, NaN or an infinity.
+// Otherwise, it returns a float64 with uniformly distributed pseudo-random bits, which can be NaN too.
+func (r *Rand) TrickyFloat64() float64 {
+	if r.Uint32n(2) == 0 {
+		return math.Float64frombits(r.Uint64())
+	}
+	i := r.Uint32n(uint32(len(trickyFloat64s)) + 1)
+	if int(i) < len(trickyFloat64s) {
+		return trickyFloat64s[i]
+	}
+	f := math.Ldexp(1, int(r.Uint32n(1023+1074+1))-1074)
+	if r.Uint32n(2) == 0 {
+		f = -f
+	}
+	return f
+}
+
+// ArgList returns a slice of pseudo-random command-line arguments, with their number in the closed
+// interval [0, maxArgs] and their lengths biased towards small values. Most arguments are words
+// or flags starting with "-", but empty arguments and arguments containing whitespace, quotes
+// or shell metacharacters are generated too. It panics if maxArgs < 0.
+func (r *Rand) ArgList(maxArgs int) []string {
+	if maxArgs < 0 {
+		panic("invalid argument to ArgList")
+	}
+	args := make([]string, r.SliceLen(maxArgs))
+	var b strings.Builder
+	for i := range args {
+		b.Reset()
+		switch r.Uint32n(8) {
+		case 0:
+		case 1:
+			// at least one special character at a pseudo-random position
+			n := 1 + r.SliceLen(15)
+			k := r.Intn(n)
+			r.randomWord(&b, alnum, k)
+			r.randomWord(&b, argSpecial, 1)
+			r.randomWord(&b, alnum+argSpecial, n-k-1)
+		case 2, 3:
+			b.WriteString("--"[:1+r.Uint32n(2)])
+			r.randomWord(&b, alnumHyphen, 1+r.SliceLen(15))
+		default:
+			r.randomWord(&b, pathSafe, 1+r.SliceLen(15))
+		}
+		args[i] = b.String()
+	}
+	return args
+}
+
+// Bitmap returns a bitmap of n bits packed into ceil(n/64) words, least significant bit first,
+// where each bit is set independently with probability density. Bits beyond n are zero.
+// The time taken is proportional to the number of words plus the number of set bits
+// (or unset bits, when density > 0.5), since the gaps between them are drawn from
+// a geometric distribution. It panics if n < 0 or density is outside of [0, 1].
+func (r *Rand) Bitmap(n int, density float64) []uint64 {
+	if n < 0 || !(density >= 0 && density <= 1) {
+		panic("invalid argument to Bitmap")
+	}
+	words := make([]uint64, (n+63)/64)
+	p, invert := density, false
+	if p > 0.5 {
+		p, invert = 1-p, true
+	}
+	r.bernoulliIndices(n, p, func(i int) {
+		words[i/64] |= 1 << (i % 64)
+	})
+	if invert {
+		for i := range words {
+			words[i] = ^words[i]
+		}
+		if n%64 != 0 {
+			words[len(words)-1] &= 1<<(n%64) - 1
+		}
+	}
+	return words
+}
+
+// URL returns a pseudo-random well-formed URL with an http or https scheme, a host name,
+// optionally a port, 0 to 3 path segments and, with probability 1/2, a query of 1 to 3 parameters.
+func (r *Rand) URL() *url.URL {
+	u := &url.URL{Scheme: "http"}
+	if r.Uint32n(2) == 0 {
+		u.Scheme = "https"
+	}
+	var b strings.Builder
+	r.domainName(&b)
+	if r.Uint32n(4) == 0 {
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(1 + int(r.Uint32n(65535))))
+	}
+	u.Host = b.String()
+	b.Reset()
+	n := r.SliceLen(3)
+	for i := 0; i < n; i++ {
+		b.WriteByte('/')
+		r.randomWord(&b, pathSafe, 1+r.SliceLen(15))
+	}
+	u.Path = b.String()
+	if r.Uint32n(2) == 0 {
+		q := url.Values{}
+		n := 1 + r.SliceLen(2)
+		for i := 0; i < n; i++ {
+			b.Reset()
+			r.randomWord(&b, alnum, 1+r.SliceLen(7))
+			k := b.String()
+			b.Reset()
+			r.randomWord(&b, alnum+argSpecial, r.SliceLen(15))
+			q.Add(k, b.String())
+		}
+		u.RawQuery = q.Encode()
+	}
+	return u
+}
+
+// Jitter returns x perturbed by a pseudo-random fraction of itself, uniformly distributed in the
+// half-open interval [-relative, relative): x*(1 + relative*(2*U-1)), with U uniform in [0, 1).
+// It panics if relative < 0.
+func (r *Rand) Jitter(x, relative float64) float64 {
+	if !(relative >= 0) {
+		panic("invalid argument to Jitter")
+	}
+	return x * (1 + relative*(2*r.Float64()-1))
+}
+
+// JitterInt returns, as an int, a uniformly distributed pseudo-random number in the closed
+// interval [x-absolute, x+absolute], clipped to the range of int. It panics if absolute < 0.
+func (r *Rand) JitterInt(x, absolute int) int {
+	if absolute < 0 {
+		panic("invalid argument to JitterInt")
+	}
+	lo, hi := x-absolute, x+absolute
+	if lo > x {
+		lo = math.MinInt
+	}
+	if hi < x {
+		hi = math.MaxInt
+	}
+	n := uint64(uint(hi-lo)) + 1 // hi-lo can overflow int, but not uint
+	if n == 0 {
+		return int(r.Uint64())
+	}
+	return lo + int(r.Uint64n(n))
+}
+
+// KeyedPairs returns n pairs for checking the stability of sorting algorithms: the first element
+// of each pair is a pseudo-random key in the half-open interval [0, keyRange), and the second one
+// is its index in the returned slice. When keyRange < n, duplicate keys are guaranteed. After a
+// stable sort by key, pairs with equal keys must remain in ascending order of their second elements.
+// It panics if n < 0 or keyRange <= 0.
+func (r *Rand) KeyedPairs(n, keyRange int) [][2]int {
+	if n < 0 || keyRange <= 0 {
+		panic("invalid argument to KeyedPairs")
+	}
+	p := make([][2]int, n)
+	for i := range p {
+		p[i] = [2]int{int(r.Uint64n(uint64(keyRange))), i}
+	}
+	return p
+}
+
+// RunLengths calls yield with alternating runs of true and false values, starting with
+// a pseudo-random value, until total values are emitted or yield returns false. Run lengths
+// are geometrically distributed with mean meanRun, except for the last run, which is truncated
+// so that the lengths sum to total. It panics if total < 0 or meanRun < 1, since runs can not be shorter than 1.
+func (r *Rand) RunLengths(total int, meanRun float64, yield func(value bool, length int) bool) {
+	if total < 0 || !(meanRun >= 1) {
+		panic("invalid argument to RunLengths")
+	}
+	lq := math.Log1p(-1 / meanRun)
+	value := r.Uint32n(2) == 0
+	for total > 0 {
+		n := total
+		if l := 1 + math.Floor(math.Log(r.float64Pos())/lq); l < float64(total) {
+			n = int(l)
+		}
+		if !yield(value, n) {
+			return
+		}
+		total -= n
+		value = !value
+	}
+}
+
+// Token returns nbytes pseudo-random bytes, read with [Rand.Read], encoded as unpadded base64url.
+// Tokens generated by this package are predictable and must not be used for security.
+// It panics if nbytes < 0.
+func (r *Rand) Token(nbytes int) string {
+	return base64.RawURLEncoding.EncodeToString(r.tokenBytes(nbytes, "Token"))
+}
+
+// HexToken returns nbytes pseudo-random bytes, read with [Rand.Read], encoded as lowercase hexadecimal.
+// Tokens generated by this package are predictable and must not be used for security.
+// It panics if nbytes < 0.
+func (r *Rand) HexToken(nbytes int) string {
+	return hex.EncodeToString(r.tokenBytes(nbytes, "HexToken"))
+}
+
+func (r *Rand) tokenBytes(nbytes int, method string) []byte {
+	if nbytes < 0 {
+		panic("invalid argument to " + method)
+	}
+	p := make([]byte, nbytes)
+	_, _ = r.Read(p)
+	return p
+}
+
+// SQLLiteral returns a pseudo-random SQL literal: an integer, a float in decimal or exponent notation,
+// a single-quoted string, NULL, TRUE or FALSE. Strings contain ordinary characters as well as quotes,
+// backslashes and other characters special to SQL or LIKE patterns, with single quotes escaped by doubling
+// as the SQL standard requires. Numbers may start with '-', which SQL tokenizes as a unary operator.
+func (r *Rand) SQLLiteral() string {
+	switch r.Uint32n(8) {
+	case 0:
+		return "NULL"
+	case 1:
+		if r.Uint32n(2) == 0 {
+			return "TRUE"
+		}
+		return "FALSE"
+	case 2, 3:
+		// the arithmetic shift results in integers of all magnitudes and both signs
+		return strconv.FormatInt(int64(r.Uint64())>>r.Uint32n(64), 10)
+	case 4, 5:
+		f := math.Ldexp(r.Float64(), int(r.Uint32n(129))-64)
+		if r.Uint32n(2) == 0 {
+			f = -f
+		}
+		return strconv.FormatFloat(f, "fe"[r.Uint32n(2)], -1, 64)
+	default:
+		var b strings.Builder
+		b.WriteByte('\'')
+		for n := r.SliceLen(32); n > 0; n-- {
+			c := alnum[r.Uint32n(uint32(len(alnum)))]
+			if r.Uint32n(4) == 0 {
+				c = sqlSpecial[r.Uint32n(uint32(len(sqlSpecial)))]
+			}
+			if c == '\'' {
+				b.WriteByte(c)
+			}
+			b.WriteByte(c)
+		}
+		b.WriteByte('\'')
+		return b.String()
+	}
+}
@@ -0,0 +1,642 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math"
+	"math/bits"
+	"net/url"
+	"path"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestRand_SliceLenBiased(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		maxLen := rapid.IntRange(0, small).Draw(t, "maxLen").(int)
+		bias := rapid.Float64Range(0, 0.99).Draw(t, "bias").(float64)
+		n := r.SliceLenBiased(maxLen, bias)
+		if n < 0 || n > maxLen {
+			t.Fatalf("got %v outside of [0, %v]", n, maxLen)
+		}
+	})
+}
+
+func TestRand_SliceLen_Distribution(t *testing.T) {
+	const (
+		maxLen = 10
+		N      = 100000
+	)
+	r := rand.New(1)
+	var counts [maxLen + 1]int
+	for i := 0; i < N; i++ {
+		counts[r.SliceLen(maxLen)]++
+	}
+	uniform := N / (maxLen + 1)
+	if counts[0] < uniform*5/4 || counts[1] < uniform*5/4 {
+		t.Errorf("small lengths are not over-represented: %v", counts)
+	}
+	if counts[maxLen] == 0 {
+		t.Errorf("max length never generated: %v", counts)
+	}
+	for i := 1; i < maxLen; i++ {
+		if counts[i] > counts[i-1] {
+			t.Errorf("length %v is more frequent than %v: %v", i, i-1, counts)
+		}
+	}
+}
+
+func TestRand_LogUniformDuration(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		min := time.Duration(rapid.Int64Range(1, math.MaxInt64-1).Draw(t, "min").(int64))
+		max := time.Duration(rapid.Int64Range(int64(min)+1, math.MaxInt64).Draw(t, "max").(int64))
+		d := r.LogUniformDuration(min, max)
+		if d < min || d >= max {
+			t.Fatalf("got %v outside of [%v, %v)", d, min, max)
+		}
+	})
+}
+
+func TestRand_LogUniformDuration_Distribution(t *testing.T) {
+	const (
+		bins = 9 // one per order of magnitude from 1ns to 1s
+		N    = 90000
+	)
+	r := rand.New(1)
+	var counts [bins]int
+	for i := 0; i < N; i++ {
+		d := r.LogUniformDuration(time.Nanosecond, time.Second)
+		counts[int(math.Log10(float64(d)))]++
+	}
+	want := float64(N) / bins
+	for i, c := range counts {
+		if math.Abs(float64(c)-want) > 5*math.Sqrt(want) {
+			t.Errorf("got %v durations in [1e%v, 1e%v) ns instead of ~%v", c, i, i+1, want)
+		}
+	}
+}
+
+func TestRand_RandomLess(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		less := r.RandomLess(n)
+		a := r.Perm(n)
+		sort.Slice(a, func(i, j int) bool { return less(a[i], a[j]) })
+		b := r.Perm(n)
+		sort.Slice(b, func(i, j int) bool { return less(b[i], b[j]) })
+		for i := range a {
+			if a[i] != b[i] {
+				t.Fatalf("sort results differ: %v vs %v", a, b)
+			}
+			if i > 0 && !less(a[i-1], a[i]) {
+				t.Fatalf("%v is not sorted", a)
+			}
+		}
+	})
+}
+
+// semverRegexp is the regular expression suggested by https://semver.org
+var semverRegexp = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+func TestRand_Version(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		v := r.Version()
+		if v[0] != 'v' || !semverRegexp.MatchString(v[1:]) {
+			t.Fatalf("got invalid version %q", v)
+		}
+	})
+}
+
+func TestRand_RaggedInts(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		rows := rapid.IntRange(0, 100).Draw(t, "rows").(int)
+		maxCols := rapid.IntRange(0, 100).Draw(t, "maxCols").(int)
+		maxVal := rapid.IntRange(1, math.MaxInt).Draw(t, "maxVal").(int)
+		v := r.RaggedInts(rows, maxCols, maxVal)
+		if len(v) != rows {
+			t.Fatalf("got %v rows instead of %v", len(v), rows)
+		}
+		for _, row := range v {
+			if len(row) > maxCols {
+				t.Fatalf("got %v columns, more than %v", len(row), maxCols)
+			}
+			for _, x := range row {
+				if x < 0 || x >= maxVal {
+					t.Fatalf("got %v outside of [0, %v)", x, maxVal)
+				}
+			}
+		}
+	})
+}
+
+func TestRand_UTF8Bytes(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		p := r.UTF8Bytes(n)
+		if !utf8.Valid(p) {
+			t.Fatalf("got invalid UTF-8 %q", p)
+		}
+		if len(p) > n || len(p) < n-3 {
+			t.Fatalf("got %v bytes instead of [%v, %v]", len(p), n-3, n)
+		}
+	})
+}
+
+func TestRand_Date(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		minYear := rapid.IntRange(1, 9999).Draw(t, "minYear").(int)
+		maxYear := rapid.IntRange(minYear, minYear+2000).Draw(t, "maxYear").(int)
+		d := r.Date(minYear, maxYear)
+		if d.Year() < minYear || d.Year() > maxYear {
+			t.Fatalf("got year %v outside of [%v, %v]", d.Year(), minYear, maxYear)
+		}
+		daysInMonth := time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		if d.Day() > daysInMonth {
+			t.Fatalf("got invalid date %v", d)
+		}
+		if d.Location() != time.UTC || d.Hour() != 0 || d.Minute() != 0 || d.Second() != 0 || d.Nanosecond() != 0 {
+			t.Fatalf("got %v instead of midnight UTC", d)
+		}
+	})
+}
+
+func TestRand_Date_Centuries(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	late := 0
+	for i := 0; i < N; i++ {
+		d := r.Date(1000, 2999)
+		if d.Year() < 1000 || d.Year() > 2999 {
+			t.Fatalf("got year %v outside of [1000, 2999]", d.Year())
+		}
+		if d.Year() >= 2000 {
+			late++
+		}
+	}
+	// both millennia have 365242 or 365243 days
+	if math.Abs(float64(late)-N/2) > 5*math.Sqrt(N/4) {
+		t.Errorf("got %v dates in [2000, 2999] instead of ~%v", late, N/2)
+	}
+}
+
+func TestRand_RGBA(t *testing.T) {
+	r := rand.New(1)
+	var seen [4][256]bool
+	for i := 0; i < 1<<16; i++ {
+		c := r.RGBA()
+		if c.A != 0xff {
+			t.Fatalf("got non-opaque color %v", c)
+		}
+		seen[0][c.R], seen[1][c.G], seen[2][c.B] = true, true, true
+		c = r.RGBATransparent()
+		if c.R > c.A || c.G > c.A || c.B > c.A {
+			t.Fatalf("got invalid alpha-premultiplied color %v", c)
+		}
+		seen[3][c.A] = true
+	}
+	for i := range seen {
+		for v, ok := range seen[i] {
+			if !ok {
+				t.Fatalf("channel %v never got value %v", i, v)
+			}
+		}
+	}
+}
+
+// emailRegexp matches addresses with a dot-atom local part and a hostname domain with a top-level domain
+var emailRegexp = regexp.MustCompile("^[0-9A-Za-z!#$%&'*+\\-/=?^_`{|}~]+(?:\\.[0-9A-Za-z!#$%&'*+\\-/=?^_`{|}~]+)*@(?:[0-9A-Za-z](?:[0-9A-Za-z-]*[0-9A-Za-z])?\\.)+[A-Za-z]{2,}$")
+
+func TestRand_Email(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		if e := r.Email(); !emailRegexp.MatchString(e) {
+			t.Fatalf("got invalid email %q", e)
+		}
+		if e := r.InvalidEmail(); emailRegexp.MatchString(e) {
+			t.Fatalf("got valid email %q", e)
+		}
+	})
+}
+
+func TestRand_FilePath(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		maxDepth := rapid.IntRange(1, 100).Draw(t, "maxDepth").(int)
+		absolute := rapid.Bool().Draw(t, "absolute").(bool)
+		p := r.FilePath(maxDepth, absolute)
+		if c := path.Clean(p); c != p {
+			t.Fatalf("got unclean path %q, cleaned to %q", p, c)
+		}
+		if path.IsAbs(p) != absolute {
+			t.Fatalf("got path %q, want absolute %v", p, absolute)
+		}
+		if depth := len(strings.Split(strings.TrimPrefix(p, "/"), "/")); depth > maxDepth {
+			t.Fatalf("got path %q of depth %v, more than %v", p, depth, maxDepth)
+		}
+	})
+}
+
+func TestRand_TrickyFloat64(t *testing.T) {
+	want := map[uint64]string{
+		0:                                      "0",
+		math.Float64bits(math.Copysign(0, -1)): "-0",
+		math.Float64bits(math.SmallestNonzeroFloat64): "smallest subnormal",
+		math.Float64bits(math.MaxFloat64):             "largest finite",
+		math.Float64bits(1):                           "1",
+		math.Float64bits(-1):                          "-1",
+		math.Float64bits(math.Inf(1)):                 "+Inf",
+		math.Float64bits(math.Inf(-1)):                "-Inf",
+	}
+	r := rand.New(1)
+	nan, pow2 := false, false
+	for i := 0; i < 10000; i++ {
+		f := r.TrickyFloat64()
+		delete(want, math.Float64bits(f))
+		nan = nan || math.IsNaN(f)
+		if fr, _ := math.Frexp(math.Abs(f)); fr == 0.5 && f != 1 && f != -1 && f != math.SmallestNonzeroFloat64 {
+			pow2 = true
+		}
+	}
+	for _, v := range want {
+		t.Errorf("%v was never generated", v)
+	}
+	if !nan {
+		t.Errorf("NaN was never generated")
+	}
+	if !pow2 {
+		t.Errorf("power of two was never generated")
+	}
+}
+
+func TestRand_ArgList(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		maxArgs := rapid.IntRange(0, 100).Draw(t, "maxArgs").(int)
+		if args := r.ArgList(maxArgs); len(args) > maxArgs {
+			t.Fatalf("got %v arguments, more than %v", len(args), maxArgs)
+		}
+	})
+}
+
+func TestRand_ArgList_EdgeCases(t *testing.T) {
+	r := rand.New(1)
+	var empty, space, quote, flag bool
+	for i := 0; i < 1000; i++ {
+		for _, a := range r.ArgList(10) {
+			empty = empty || a == ""
+			space = space || strings.ContainsAny(a, " \t")
+			quote = quote || strings.ContainsAny(a, "'\"")
+			flag = flag || strings.HasPrefix(a, "-")
+		}
+	}
+	if !empty || !space || !quote || !flag {
+		t.Errorf("edge cases not generated: empty %v, whitespace %v, quotes %v, flags %v", empty, space, quote, flag)
+	}
+}
+
+func TestRand_Bitmap(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		density := rapid.Float64Range(0, 1).Draw(t, "density").(float64)
+		b := r.Bitmap(n, density)
+		if len(b) != (n+63)/64 {
+			t.Fatalf("got %v words instead of %v", len(b), (n+63)/64)
+		}
+		if n%64 != 0 && b[len(b)-1]>>(n%64) != 0 {
+			t.Fatalf("got bits set beyond %v: %#x", n, b[len(b)-1])
+		}
+	})
+}
+
+func TestRand_Bitmap_Density(t *testing.T) {
+	const n = 100003
+	r := rand.New(1)
+	for _, density := range []float64{0, 0.001, 0.1, 0.5, 0.7, 0.999, 1} {
+		count := 0
+		for _, w := range r.Bitmap(n, density) {
+			count += bits.OnesCount64(w)
+		}
+		want := n * density
+		if math.Abs(float64(count)-want) > 5*math.Sqrt(n*density*(1-density)) {
+			t.Errorf("density %v: got %v set bits instead of ~%v", density, count, want)
+		}
+	}
+}
+
+func TestRand_URL(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		u := r.URL()
+		v, err := url.Parse(u.String())
+		if err != nil {
+			t.Fatalf("failed to parse %q: %v", u, err)
+		}
+		if !reflect.DeepEqual(u, v) {
+			t.Fatalf("got %#v after round trip instead of %#v", v, u)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			t.Fatalf("got scheme %q", u.Scheme)
+		}
+	})
+}
+
+func TestRand_Jitter(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		x := rapid.Float64Range(-1e300, 1e300).Draw(t, "x").(float64)
+		relative := rapid.Float64Range(0, 10).Draw(t, "relative").(float64)
+		y := r.Jitter(x, relative)
+		// allow a few ulps of rounding error in x*(1+relative*(2u-1))
+		ax := math.Abs(x)
+		if d := math.Abs(y - x); d > relative*ax+4*(math.Nextafter(ax, math.Inf(1))-ax) {
+			t.Fatalf("got %v, %v away from %v", y, d, x)
+		}
+	})
+}
+
+func TestRand_JitterInt(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		x := rapid.Int().Draw(t, "x").(int)
+		absolute := rapid.IntMin(0).Draw(t, "absolute").(int)
+		y := r.JitterInt(x, absolute)
+		d := uint64(y - x)
+		if y < x {
+			d = uint64(x - y)
+		}
+		if d > uint64(absolute) {
+			t.Fatalf("got %v, %v away from %v", y, d, x)
+		}
+	})
+}
+
+func TestRand_JitterInt_Large(t *testing.T) {
+	r := rand.New(1)
+	for _, x := range []int{0, -1, 1, math.MinInt / 4, math.MaxInt / 4} {
+		absolute := math.MaxInt/2 + 100
+		for i := 0; i < 1000; i++ {
+			y := r.JitterInt(x, absolute)
+			d := uint(y - x)
+			if y < x {
+				d = uint(x - y)
+			}
+			if d > uint(absolute) {
+				t.Fatalf("got %v, %v away from %v", y, d, x)
+			}
+		}
+	}
+}
+
+func TestRand_KeyedPairs(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		keyRange := rapid.IntRange(1, 2*small).Draw(t, "keyRange").(int)
+		p := r.KeyedPairs(n, keyRange)
+		ids := make([]int, len(p))
+		keys := map[int]bool{}
+		for i, kv := range p {
+			if kv[0] < 0 || kv[0] >= keyRange {
+				t.Fatalf("got key %v outside of [0, %v)", kv[0], keyRange)
+			}
+			keys[kv[0]] = true
+			ids[i] = kv[1]
+		}
+		if !isPerm(ids) {
+			t.Fatalf("sequence ids %v are not a permutation", ids)
+		}
+		if keyRange < n && len(keys) == n {
+			t.Fatalf("got no duplicate keys for %v pairs in key range %v", n, keyRange)
+		}
+		sort.SliceStable(p, func(i, j int) bool { return p[i][0] < p[j][0] })
+		for i := 1; i < len(p); i++ {
+			if p[i][0] == p[i-1][0] && p[i][1] < p[i-1][1] {
+				t.Fatalf("stable sort reordered pairs %v and %v", p[i-1], p[i])
+			}
+		}
+	})
+}
+
+func TestRand_RunLengths(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		total := rapid.IntRange(0, small).Draw(t, "total").(int)
+		meanRun := rapid.Float64Range(1, 100).Draw(t, "meanRun").(float64)
+		sum, first := 0, true
+		var prev bool
+		r.RunLengths(total, meanRun, func(value bool, length int) bool {
+			if length <= 0 {
+				t.Fatalf("got run length %v", length)
+			}
+			if !first && value == prev {
+				t.Fatalf("runs do not alternate")
+			}
+			first, prev = false, value
+			sum += length
+			return true
+		})
+		if sum != total {
+			t.Fatalf("got runs of total length %v instead of %v", sum, total)
+		}
+	})
+}
+
+func TestRand_RunLengths_Mean(t *testing.T) {
+	const total = 1000000
+	r := rand.New(1)
+	for _, meanRun := range []float64{1, 1.5, 4, 30} {
+		runs := 0
+		r.RunLengths(total, meanRun, func(bool, int) bool {
+			runs++
+			return true
+		})
+		if m := float64(total) / float64(runs); math.Abs(m-meanRun) > 0.02*meanRun {
+			t.Errorf("got mean run length %v instead of %v", m, meanRun)
+		}
+	}
+	stopped := 0
+	r.RunLengths(total, 2, func(bool, int) bool {
+		stopped++
+		return stopped < 3
+	})
+	if stopped != 3 {
+		t.Errorf("got %v runs after yield returned false", stopped)
+	}
+}
+
+// hostnameLabelRegexp matches a hostname label per RFC 1123
+var hostnameLabelRegexp = regexp.MustCompile(`^[0-9A-Za-z]([0-9A-Za-z-]{0,61}[0-9A-Za-z])?$`)
+
+func TestRand_Hostname(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		h := r.Hostname()
+		if len(h) > 253 {
+			t.Fatalf("got hostname %q of length %v", h, len(h))
+		}
+		for _, l := range strings.Split(h, ".") {
+			if !hostnameLabelRegexp.MatchString(l) {
+				t.Fatalf("got hostname %q with invalid label %q", h, l)
+			}
+		}
+	})
+}
+
+func TestRand_Token(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		if p, err := base64.RawURLEncoding.DecodeString(r.Token(n)); err != nil || len(p) != n {
+			t.Fatalf("got %v bytes and error %v instead of %v bytes", len(p), err, n)
+		}
+		if p, err := hex.DecodeString(r.HexToken(n)); err != nil || len(p) != n {
+			t.Fatalf("got %v bytes and error %v instead of %v bytes", len(p), err, n)
+		}
+	})
+}
+
+func TestRand_Token_Distinct(t *testing.T) {
+	r := rand.New(1)
+	seen := map[string]bool{}
+	for i := 0; i < 10000; i++ {
+		for _, tok := range []string{r.Token(16), r.HexToken(16)} {
+			if seen[tok] {
+				t.Fatalf("got repeated token %q", tok)
+			}
+			seen[tok] = true
+		}
+	}
+}
+
+// sqlLiteralToken scans a single SQL literal token at the start of s, after an optional unary minus,
+// and returns its kind and the unescaped contents of a string literal, or "" if there is none.
+func sqlLiteralToken(s string) (kind string, str string, n int) {
+	i := 0
+	if strings.HasPrefix(s, "-") {
+		i++
+	}
+	for _, k := range []string{"NULL", "TRUE", "FALSE"} {
+		if strings.HasPrefix(s, k) {
+			return "keyword", "", len(k)
+		}
+	}
+	switch {
+	case i == 0 && strings.HasPrefix(s, "'"):
+		var b strings.Builder
+		for i = 1; i < len(s); i++ {
+			if s[i] != '\'' {
+				b.WriteByte(s[i])
+				continue
+			}
+			if i+1 < len(s) && s[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+				continue
+			}
+			return "string", b.String(), i + 1
+		}
+		return "", "", 0 // unterminated
+	case i < len(s) && s[i] >= '0' && s[i] <= '9':
+		digits := func() int {
+			j := i
+			for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+				i++
+			}
+			return i - j
+		}
+		kind = "integer"
+		digits()
+		if i < len(s) && s[i] == '.' {
+			i++
+			digits()
+			kind = "float"
+		}
+		if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+			i++
+			if i < len(s) && (s[i] == '+' || s[i] == '-') {
+				i++
+			}
+			if digits() == 0 {
+				return "", "", 0
+			}
+			kind = "float"
+		}
+		return kind, "", i
+	}
+	return "", "", 0
+}
+
+func TestRand_SQLLiteral(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		l := r.SQLLiteral()
+		kind, str, n := sqlLiteralToken(l)
+		if kind == "" || n != len(l) {
+			t.Fatalf("tokenizer rejected literal %q", l)
+		}
+		if kind == "string" && strings.Count(l[1:len(l)-1], "'") != 2*strings.Count(str, "'") {
+			t.Fatalf("got unescaped quote in literal %q", l)
+		}
+	})
+}
+
+func TestRand_SQLLiteral_Quotes(t *testing.T) {
+	r := rand.New(1)
+	kinds := map[string]int{}
+	quoted := 0
+	for i := 0; i < 10000; i++ {
+		kind, str, _ := sqlLiteralToken(r.SQLLiteral())
+		kinds[kind]++
+		if strings.Contains(str, "'") {
+			quoted++
+		}
+	}
+	for _, k := range []string{"keyword", "integer", "float", "string"} {
+		if kinds[k] == 0 {
+			t.Errorf("no %v literals generated", k)
+		}
+	}
+	if quoted == 0 {
+		t.Errorf("no strings with embedded quotes generated")
+	}
+}
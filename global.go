@@ -22,6 +22,11 @@ func Float64() float64 {
 	return float64(rand64()&int53Mask) * f53Mul
 }
 
+// float64Pos is like Float64, but returns a number in the open interval (0.0, 1.0).
+func float64Pos() float64 {
+	return (float64(rand64()&int53Mask) + 0.5) * f53Mul
+}
+
 // Int returns a uniformly distributed non-negative pseudo-random int.
 func Int() int {
 	return int(rand64() & intMask)
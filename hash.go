@@ -0,0 +1,32 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+// hash64 returns a 64-bit hash of seed and index, without involving any generator state.
+// Both inputs are passed through the moremur mixer (a variant of the MurmurHash3 finalizer
+// with better constants), with the index offset by the golden ratio so that index 0 does not
+// map seed to itself.
+func hash64(seed, index uint64) uint64 {
+	return moremur(seed ^ moremur(index+0x9e3779b97f4a7c15))
+}
+
+func moremur(x uint64) uint64 {
+	x ^= x >> 27
+	x *= 0x3c79ac492ba7b653
+	x ^= x >> 33
+	x *= 0x1c69b3f74ac4ae35
+	x ^= x >> 27
+	return x
+}
+
+// HashFloat64 returns a float64 in the half-open interval [0.0, 1.0) that is a deterministic
+// function of seed and index, for procedural generation keyed by coordinates or indices.
+// Like [Rand.Float64], it uses the top 53 bits of a 64-bit hash as the mantissa, so all
+// results are multiples of 2^-53. It does not use or modify any generator.
+func HashFloat64(seed, index uint64) float64 {
+	return float64(hash64(seed, index)>>11) * f53Mul
+}
@@ -0,0 +1,52 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+func TestHashFloat64(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		seed := rapid.Uint64().Draw(t, "seed").(uint64)
+		index := rapid.Uint64().Draw(t, "index").(uint64)
+		f := rand.HashFloat64(seed, index)
+		if f < 0 || f >= 1 {
+			t.Fatalf("got %v outside of [0, 1)", f)
+		}
+		if g := rand.HashFloat64(seed, index); g != f {
+			t.Fatalf("got %v and %v for the same seed and index", f, g)
+		}
+	})
+}
+
+func TestHashFloat64_Decorrelated(t *testing.T) {
+	const N = 100000
+	for _, seed := range []uint64{0, 1, 0xdeadbeef} {
+		x := make([]float64, N)
+		y := make([]float64, N)
+		z := make([]float64, N)
+		for i := range x {
+			x[i] = rand.HashFloat64(seed, uint64(i))
+			y[i] = rand.HashFloat64(seed, uint64(i)+1)
+			z[i] = rand.HashFloat64(seed+1, uint64(i))
+		}
+		m, _ := meanVariance(x)
+		if math.Abs(m-0.5) > 5*math.Sqrt(1.0/12/N) {
+			t.Errorf("seed %v: got mean %v instead of 0.5", seed, m)
+		}
+		if c := correlation(x, y); math.Abs(c) > 5/math.Sqrt(N) {
+			t.Errorf("seed %v: got correlation %v between adjacent indices", seed, c)
+		}
+		if c := correlation(x, z); math.Abs(c) > 5/math.Sqrt(N) {
+			t.Errorf("seed %v: got correlation %v between adjacent seeds", seed, c)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import (
+	"errors"
+	"math"
+)
+
+// A HistogramDist samples from a piecewise-constant distribution described by a histogram.
+type HistogramDist struct {
+	edges []float64
+	cum   []float64
+}
+
+// NewHistogramDist returns a distribution where the bin [edges[i], edges[i+1]) is selected
+// with probability proportional to counts[i], and values are uniformly distributed within bins.
+// It returns an error unless len(edges) == len(counts)+1, edges are finite and strictly increasing,
+// and counts are valid weights.
+func NewHistogramDist(edges []float64, counts []float64) (*HistogramDist, error) {
+	if len(edges) != len(counts)+1 {
+		return nil, errors.New("rand: histogram must have one more edge than counts")
+	}
+	for i, e := range edges {
+		if math.IsInf(e, 0) || math.IsNaN(e) || (i > 0 && !(e > edges[i-1])) {
+			return nil, errors.New("rand: histogram edges must be finite and strictly increasing")
+		}
+	}
+	cum, err := cumulativeWeights(counts)
+	if err != nil {
+		return nil, err
+	}
+	return &HistogramDist{edges: append([]float64(nil), edges...), cum: cum}, nil
+}
+
+// Sample returns a value drawn from the distribution.
+func (h *HistogramDist) Sample(r *Rand) float64 {
+	i := r.searchCumulative(h.cum)
+	lo, hi := h.edges[i], h.edges[i+1]
+	x := lo + (hi-lo)*r.Float64()
+	if x >= hi { // rounding errors
+		return lo
+	}
+	return x
+}
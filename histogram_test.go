@@ -0,0 +1,58 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"sort"
+	"testing"
+)
+
+func TestNewHistogramDist_Invalid(t *testing.T) {
+	for _, c := range []struct{ edges, counts []float64 }{
+		{[]float64{0, 1}, []float64{1, 1}},
+		{[]float64{0, 1, 1}, []float64{1, 1}},
+		{[]float64{1, 0}, []float64{1}},
+		{[]float64{0, math.Inf(1)}, []float64{1}},
+		{[]float64{0, 1}, []float64{0}},
+		{[]float64{0, 1, 2}, []float64{1, -1}},
+	} {
+		if _, err := rand.NewHistogramDist(c.edges, c.counts); err == nil {
+			t.Errorf("no error for edges %v and counts %v", c.edges, c.counts)
+		}
+	}
+}
+
+func TestHistogramDist_Resample(t *testing.T) {
+	const N = 100000
+	edges := []float64{-3, -1, 0, 0.5, 2, 10}
+	counts := []float64{5, 0, 20, 10, 1}
+	h, err := rand.NewHistogramDist(edges, counts)
+	if err != nil {
+		t.Fatalf("got unexpected error: %v", err)
+	}
+	r := rand.New(1)
+	got := make([]float64, len(counts))
+	for i := 0; i < N; i++ {
+		x := h.Sample(r)
+		if x < edges[0] || x >= edges[len(edges)-1] {
+			t.Fatalf("got %v outside of [%v, %v)", x, edges[0], edges[len(edges)-1])
+		}
+		got[sort.Search(len(edges), func(i int) bool { return edges[i] > x })-1]++
+	}
+	total := 0.0
+	for _, c := range counts {
+		total += c
+	}
+	for i, c := range counts {
+		p := c / total
+		if math.Abs(got[i]/N-p) > 5*math.Sqrt(p*(1-p)/N) {
+			t.Errorf("bin %v has frequency %v instead of %v", i, got[i]/N, p)
+		}
+	}
+}
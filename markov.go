@@ -0,0 +1,47 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import (
+	"errors"
+	"math"
+)
+
+// A Markov is a discrete-time Markov chain over the states 0 to n-1.
+type Markov struct {
+	cum [][]float64
+}
+
+// NewMarkov returns a Markov chain where transition[i][j] is the probability of moving from state i
+// to state j. It returns an error if transition is not square, or if any row contains invalid weights
+// or does not sum to 1 (within a relative error of 1e-9).
+func NewMarkov(transition [][]float64) (*Markov, error) {
+	m := &Markov{cum: make([][]float64, len(transition))}
+	for i, row := range transition {
+		if len(row) != len(transition) {
+			return nil, errors.New("rand: Markov transition matrix is not square")
+		}
+		cum, err := cumulativeWeights(row)
+		if err != nil {
+			return nil, err
+		}
+		if math.Abs(cum[len(cum)-1]-1) > 1e-9 {
+			return nil, errors.New("rand: Markov transition probabilities do not sum to 1")
+		}
+		m.cum[i] = cum
+	}
+	return m, nil
+}
+
+// Next returns the state following state, chosen according to the transition probabilities.
+// It panics if state is not a valid state.
+func (m *Markov) Next(r *Rand, state int) int {
+	if state < 0 || state >= len(m.cum) {
+		panic("invalid argument to Next")
+	}
+	return r.searchCumulative(m.cum[state])
+}
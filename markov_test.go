@@ -0,0 +1,51 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"testing"
+)
+
+func TestMarkov_Stationary(t *testing.T) {
+	const (
+		N = 100000
+		a = 0.1 // 0 -> 1
+		b = 0.3 // 1 -> 0
+	)
+	m, err := rand.NewMarkov([][]float64{{1 - a, a}, {b, 1 - b}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r := rand.New(1)
+	state, zeros := 0, 0
+	for i := 0; i < N; i++ {
+		state = m.Next(r, state)
+		if state == 0 {
+			zeros++
+		}
+	}
+	// stationary distribution is (b, a) / (a + b); consecutive states are correlated, hence the wider margin
+	if f, want := float64(zeros)/N, b/(a+b); math.Abs(f-want) > 0.01 {
+		t.Errorf("got state 0 with frequency %v instead of %v", f, want)
+	}
+}
+
+func TestNewMarkov_Invalid(t *testing.T) {
+	for _, tr := range [][][]float64{
+		{{1, 0}},
+		{{0.5, 0.5}, {0.5}},
+		{{0.5, 0.6}, {0.5, 0.5}},
+		{{1.5, -0.5}, {0.5, 0.5}},
+		{{0, 0}, {0.5, 0.5}},
+	} {
+		if _, err := rand.NewMarkov(tr); err == nil {
+			t.Errorf("got no error for transition matrix %v", tr)
+		}
+	}
+}
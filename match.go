@@ -0,0 +1,119 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	matchMaxRepeat = 10
+)
+
+// MatchOf returns a pseudo-random string that matches the regular expression pattern,
+// using the syntax accepted by [regexp.Compile]. Unbounded repetitions, such as x* and x+,
+// are repeated at most 10 more times than required. Anchors such as ^ and $ are assumed
+// to be satisfied, and MatchOf returns an error for patterns with word boundaries,
+// as well as for patterns that fail to parse.
+func (r *Rand) MatchOf(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := r.match(&b, re); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func (r *Rand) match(b *strings.Builder, re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText:
+		return nil
+	case syntax.OpLiteral:
+		for _, c := range re.Rune {
+			b.WriteRune(c)
+		}
+		return nil
+	case syntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return fmt.Errorf("rand: empty character class in %v", re)
+		}
+		b.WriteRune(r.classRune(re.Rune))
+		return nil
+	case syntax.OpAnyCharNotNL:
+		c := r.utf8Rune()
+		for c == '\n' {
+			c = r.utf8Rune()
+		}
+		b.WriteRune(c)
+		return nil
+	case syntax.OpAnyChar:
+		b.WriteRune(r.utf8Rune())
+		return nil
+	case syntax.OpCapture:
+		return r.match(b, re.Sub[0])
+	case syntax.OpStar:
+		return r.matchRepeat(b, re.Sub[0], 0, -1)
+	case syntax.OpPlus:
+		return r.matchRepeat(b, re.Sub[0], 1, -1)
+	case syntax.OpQuest:
+		return r.matchRepeat(b, re.Sub[0], 0, 1)
+	case syntax.OpRepeat:
+		return r.matchRepeat(b, re.Sub[0], re.Min, re.Max)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := r.match(b, sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	case syntax.OpAlternate:
+		return r.match(b, re.Sub[r.Uint64n(uint64(len(re.Sub)))])
+	default:
+		return fmt.Errorf("rand: unsupported regular expression construct %v", re)
+	}
+}
+
+func (r *Rand) matchRepeat(b *strings.Builder, re *syntax.Regexp, min int, max int) error {
+	if max < 0 {
+		max = min + matchMaxRepeat
+	}
+	n := min + int(r.Uint64n(uint64(max-min)+1))
+	for i := 0; i < n; i++ {
+		if err := r.match(b, re); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// classRune returns a rune chosen uniformly from the ranges [lo, hi] listed as pairs in class,
+// excluding surrogate halves.
+func (r *Rand) classRune(class []rune) rune {
+	total := uint64(0)
+	for i := 0; i < len(class); i += 2 {
+		total += uint64(class[i+1]-class[i]) + 1
+	}
+	for {
+		k := r.Uint64n(total)
+		for i := 0; i < len(class); i += 2 {
+			n := uint64(class[i+1]-class[i]) + 1
+			if k < n {
+				if c := class[i] + rune(k); utf8.ValidRune(c) {
+					return c
+				}
+				break
+			}
+			k -= n
+		}
+	}
+}
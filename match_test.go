@@ -0,0 +1,52 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"regexp"
+	"testing"
+)
+
+func TestRand_MatchOf(t *testing.T) {
+	patterns := []string{
+		``,
+		`abc`,
+		`^[a-z]+@[a-z]+\.(com|org|net)$`,
+		`\d{3}-\d{4}`,
+		`(?i)hello,? world!*`,
+		`x{2,5}y{3}z{1,}`,
+		`[^a-z\s]{0,8}`,
+		`.+\n?.*`,
+		`(?s).{3}`,
+		`\p{Greek}+\P{L}`,
+		`(a|b(c|d)*)+e?`,
+	}
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		for _, p := range patterns {
+			m, err := r.MatchOf(p)
+			if err != nil {
+				t.Fatalf("pattern %q: got unexpected error: %v", p, err)
+			}
+			if !regexp.MustCompile(`^(?:` + p + `)$`).MatchString(m) {
+				t.Fatalf("pattern %q: got non-matching %q", p, m)
+			}
+		}
+	})
+}
+
+func TestRand_MatchOf_Unsupported(t *testing.T) {
+	r := rand.New(1)
+	for _, p := range []string{`(`, `a\bc`, `\B`, `[^\x00-\x{10FFFF}]`} {
+		if _, err := r.MatchOf(p); err == nil {
+			t.Errorf("pattern %q: no error", p)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "math"
+
+// RandomCovariance returns a pseudo-random symmetric positive-definite n×n matrix,
+// suitable for use as a covariance matrix. The matrix is computed as L*Lᵀ, where L
+// is lower-triangular with standard normal entries below the diagonal and diagonal
+// entries uniformly distributed in [1, 2), which guarantees positive-definiteness.
+// It panics if n < 0.
+func (r *Rand) RandomCovariance(n int) [][]float64 {
+	if n < 0 {
+		panic("invalid argument to RandomCovariance")
+	}
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, i+1)
+		for j := 0; j < i; j++ {
+			l[i][j] = r.NormFloat64()
+		}
+		l[i][i] = 1 + r.Float64()
+	}
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			s := 0.0
+			for k := 0; k <= j; k++ {
+				s += l[i][k] * l[j][k]
+			}
+			m[i][j] = s
+			m[j][i] = s
+		}
+	}
+	return m
+}
+
+// PointInBox returns a point drawn uniformly from the n-dimensional axis-aligned box
+// [min[0], max[0]) × … × [min[n-1], max[n-1]). It panics if len(min) != len(max),
+// or if min[i] >= max[i] or either bound is not finite in any dimension.
+func (r *Rand) PointInBox(min, max []float64) []float64 {
+	if len(min) != len(max) {
+		panic("invalid argument to PointInBox")
+	}
+	for i := range min {
+		if !(min[i] < max[i]) || math.IsInf(min[i], 0) || math.IsInf(max[i], 0) {
+			panic("invalid argument to PointInBox")
+		}
+	}
+	p := make([]float64, len(min))
+	for i := range p {
+		for {
+			// the weighted sum avoids overflow of max-min; values rounded up to max are rejected
+			u := r.Float64()
+			p[i] = min[i]*(1-u) + max[i]*u
+			if min[i] <= p[i] && p[i] < max[i] {
+				break
+			}
+		}
+	}
+	return p
+}
+
+// SparseCOO returns a pseudo-random rows×cols sparse matrix in coordinate format: the row indices,
+// column indices and values of its nonzero entries, in row-major order. Every cell is nonzero
+// independently with probability density, and nonzero values are uniformly distributed in
+// [-1, 0) ∪ (0, 1]. The time taken is proportional to the number of nonzero entries, since the gaps
+// between them are drawn from a geometric distribution. It panics if rows < 0, cols < 0,
+// rows*cols overflows an int, or density is outside of [0, 1].
+func (r *Rand) SparseCOO(rows, cols int, density float64) (ri, ci []int, vals []float64) {
+	if rows < 0 || cols < 0 || (cols > 0 && rows > math.MaxInt/cols) || !(density >= 0 && density <= 1) {
+		panic("invalid argument to SparseCOO")
+	}
+	r.bernoulliIndices(rows*cols, density, func(i int) {
+		ri = append(ri, i/cols)
+		ci = append(ci, i%cols)
+		v := 1 - r.Float64()
+		if r.Uint32n(2) == 0 {
+			v = -v
+		}
+		vals = append(vals, v)
+	})
+	return ri, ci, vals
+}
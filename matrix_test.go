@@ -0,0 +1,128 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+// cholesky reports whether the Cholesky decomposition of m succeeds.
+func cholesky(m [][]float64) bool {
+	n := len(m)
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			s := m[i][j]
+			for k := 0; k < j; k++ {
+				s -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if s <= 0 {
+					return false
+				}
+				l[i][i] = math.Sqrt(s)
+			} else {
+				l[i][j] = s / l[j][j]
+			}
+		}
+	}
+	return true
+}
+
+func TestRand_RandomCovariance(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, 20).Draw(t, "n").(int)
+		m := r.RandomCovariance(n)
+		if len(m) != n {
+			t.Fatalf("got %v rows instead of %v", len(m), n)
+		}
+		for i := range m {
+			if len(m[i]) != n {
+				t.Fatalf("got %v columns in row %v instead of %v", len(m[i]), i, n)
+			}
+			for j := range m[i] {
+				if m[i][j] != m[j][i] {
+					t.Fatalf("matrix is not symmetric at (%v, %v): %v", i, j, m)
+				}
+			}
+		}
+		if !cholesky(m) {
+			t.Fatalf("matrix is not positive-definite: %v", m)
+		}
+	})
+}
+
+func TestRand_PointInBox(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, 10).Draw(t, "n").(int)
+		min := make([]float64, n)
+		max := make([]float64, n)
+		for i := range min {
+			min[i] = rapid.Float64Range(-1e300, 1e300).Draw(t, "min").(float64)
+			max[i] = math.Nextafter(min[i], math.Inf(1)) + rapid.Float64Range(0, 1e300).Draw(t, "width").(float64)
+		}
+		p := r.PointInBox(min, max)
+		if len(p) != n {
+			t.Fatalf("got %v dimensions instead of %v", len(p), n)
+		}
+		for i := range p {
+			if p[i] < min[i] || p[i] >= max[i] {
+				t.Fatalf("coordinate %v is %v, outside of [%v, %v)", i, p[i], min[i], max[i])
+			}
+		}
+	})
+}
+
+func TestRand_SparseCOO(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		rows := rapid.IntRange(0, 100).Draw(t, "rows").(int)
+		cols := rapid.IntRange(0, 100).Draw(t, "cols").(int)
+		density := rapid.Float64Range(0, 1).Draw(t, "density").(float64)
+		ri, ci, vals := r.SparseCOO(rows, cols, density)
+		if len(ri) != len(ci) || len(ri) != len(vals) {
+			t.Fatalf("got %v row indices, %v column indices and %v values", len(ri), len(ci), len(vals))
+		}
+		for k := range ri {
+			if ri[k] < 0 || ri[k] >= rows || ci[k] < 0 || ci[k] >= cols {
+				t.Fatalf("got entry (%v, %v) outside of %v×%v", ri[k], ci[k], rows, cols)
+			}
+			if k > 0 && ri[k]*cols+ci[k] <= ri[k-1]*cols+ci[k-1] {
+				t.Fatalf("entry (%v, %v) is duplicate or out of order", ri[k], ci[k])
+			}
+			if vals[k] == 0 || vals[k] < -1 || vals[k] > 1 {
+				t.Fatalf("got value %v", vals[k])
+			}
+		}
+	})
+}
+
+func TestRand_SparseCOO_Density(t *testing.T) {
+	const (
+		rows = 300
+		cols = 400
+	)
+	r := rand.New(1)
+	for _, density := range []float64{0, 0.0001, 0.01, 0.3, 1} {
+		ri, _, _ := r.SparseCOO(rows, cols, density)
+		n := float64(rows * cols)
+		if want := n * density; math.Abs(float64(len(ri))-want) > 5*math.Sqrt(n*density*(1-density)) {
+			t.Errorf("density %v: got %v entries instead of ~%v", density, len(ri), want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "errors"
+
+// A Mixture samples from a weighted mixture of distributions.
+type Mixture struct {
+	cum   []float64
+	comps []func(*Rand) float64
+}
+
+// NewMixture returns a mixture of the distributions comps, where comps[i] is selected
+// with probability proportional to weights[i]. It returns an error if the lengths
+// of weights and comps differ, or if the weights are invalid.
+func NewMixture(weights []float64, comps []func(*Rand) float64) (*Mixture, error) {
+	if len(weights) != len(comps) {
+		return nil, errors.New("rand: mixture weights and components differ in length")
+	}
+	for _, c := range comps {
+		if c == nil {
+			return nil, errors.New("rand: nil mixture component")
+		}
+	}
+	cum, err := cumulativeWeights(weights)
+	if err != nil {
+		return nil, err
+	}
+	return &Mixture{cum: cum, comps: append([]func(*Rand) float64(nil), comps...)}, nil
+}
+
+// Sample selects a component of the mixture according to the weights and returns a value drawn from it.
+func (m *Mixture) Sample(r *Rand) float64 {
+	return m.comps[r.searchCumulative(m.cum)](r)
+}
@@ -0,0 +1,51 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"testing"
+)
+
+func TestNewMixture_Invalid(t *testing.T) {
+	comp := func(r *rand.Rand) float64 { return r.Float64() }
+	for _, w := range [][]float64{nil, {1}, {0, 0}, {-1, 2}, {math.NaN(), 1}, {math.Inf(1), 1}} {
+		if _, err := rand.NewMixture(w, []func(*rand.Rand) float64{comp, comp}); err == nil {
+			t.Errorf("no error for weights %v", w)
+		}
+	}
+}
+
+func TestMixture_Bimodal(t *testing.T) {
+	const N = 100000
+	m, err := rand.NewMixture([]float64{1, 3}, []func(*rand.Rand) float64{
+		func(r *rand.Rand) float64 { return r.NormFloat64() - 10 },
+		func(r *rand.Rand) float64 { return r.NormFloat64() + 10 },
+	})
+	if err != nil {
+		t.Fatalf("got unexpected error: %v", err)
+	}
+	r := rand.New(1)
+	var lo, mid, hi int
+	for i := 0; i < N; i++ {
+		switch x := m.Sample(r); {
+		case x < -5:
+			lo++
+		case x > 5:
+			hi++
+		default:
+			mid++
+		}
+	}
+	if mid > N/1000 {
+		t.Errorf("%v samples between the modes", mid)
+	}
+	if p := float64(lo) / N; math.Abs(p-0.25) > 0.01 {
+		t.Errorf("got %v of samples in the low mode instead of 0.25", p)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+// A NoRepeat generates indices that never repeat the immediately previous one,
+// like a playlist shuffle that does not play the same song twice in a row.
+type NoRepeat struct {
+	n    int
+	prev int
+}
+
+// NewNoRepeat returns a NoRepeat that generates indices in the half-open interval [0, n).
+// It panics if n < 2.
+func NewNoRepeat(n int) *NoRepeat {
+	if n < 2 {
+		panic("invalid argument to NewNoRepeat")
+	}
+	return &NoRepeat{n: n, prev: -1}
+}
+
+// Next returns a uniformly distributed pseudo-random index in the half-open interval [0, n),
+// other than the one returned by the previous call.
+func (nr *NoRepeat) Next(r *Rand) int {
+	if nr.prev < 0 {
+		nr.prev = r.Intn(nr.n)
+	} else {
+		nr.prev = r.IntnExcept(nr.n, nr.prev)
+	}
+	return nr.prev
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+func TestNoRepeat_Next(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		n := rapid.IntRange(2, small).Draw(t, "n").(int)
+		r := rand.New(s)
+		nr := rand.NewNoRepeat(n)
+		prev := -1
+		for i := 0; i < small; i++ {
+			v := nr.Next(r)
+			if v < 0 || v >= n || v == prev {
+				t.Fatalf("got %v after %v, outside of [0, %v) or repeated", v, prev, n)
+			}
+			prev = v
+		}
+	})
+}
+
+func TestNoRepeat_Uniform(t *testing.T) {
+	const (
+		n = 5
+		N = 100000
+	)
+	r := rand.New(1)
+	nr := rand.NewNoRepeat(n)
+	var counts [n]int
+	for i := 0; i < N; i++ {
+		counts[nr.Next(r)]++
+	}
+	want := float64(N) / n
+	for i, c := range counts {
+		if math.Abs(float64(c)-want) > 5*math.Sqrt(want) {
+			t.Errorf("index %v generated %v times instead of ~%v", i, c, want)
+		}
+	}
+}
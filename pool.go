@@ -0,0 +1,47 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// A Pool is a set of generators that can be used by multiple goroutines without locking.
+// A Pool is safe for concurrent use by multiple goroutines, but the generators it
+// returns are not.
+//
+// Every generator created by the pool is seeded with the pool seed and the sequential
+// number of the generator, so the k-th created generator always produces the same stream.
+// Which goroutine gets which generator is not deterministic, though.
+type Pool struct {
+	seed  uint64
+	count uint64
+	pool  sync.Pool
+}
+
+// NewPool returns a Pool that derives generators from seed.
+func NewPool(seed uint64) *Pool {
+	p := &Pool{seed: seed}
+	p.pool.New = func() interface{} {
+		var r Rand
+		r.init3(p.seed, atomic.AddUint64(&p.count, 1), 0)
+		return &r
+	}
+	return p
+}
+
+// Get returns a generator from the pool, creating a new one if necessary.
+// The generator must not be used by multiple goroutines concurrently.
+func (p *Pool) Get() *Rand {
+	return p.pool.Get().(*Rand)
+}
+
+// Put returns r to the pool. r must not be used after Put.
+func (p *Pool) Put(r *Rand) {
+	p.pool.Put(r)
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"sync"
+	"testing"
+)
+
+func correlation(x, y []float64) float64 {
+	mx, vx := meanVariance(x)
+	my, vy := meanVariance(y)
+	c := 0.0
+	for i := range x {
+		c += (x[i] - mx) * (y[i] - my)
+	}
+	return c / float64(len(x)-1) / math.Sqrt(vx*vy)
+}
+
+func TestPool_Concurrent(t *testing.T) {
+	p := rand.NewPool(1)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				r := p.Get()
+				if f := r.Float64(); f < 0 || f >= 1 {
+					t.Errorf("got %v outside of [0, 1)", f)
+				}
+				p.Put(r)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPool_Decorrelated(t *testing.T) {
+	const (
+		n = 8
+		N = 10000
+	)
+	p := rand.NewPool(1)
+	var samples [n][]float64
+	for i := range samples {
+		r := p.Get()
+		samples[i] = make([]float64, N)
+		for j := range samples[i] {
+			samples[i][j] = r.Float64()
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if c := correlation(samples[i], samples[j]); math.Abs(c) > 5/math.Sqrt(N) {
+				t.Errorf("generators %v and %v have correlation %v", i, j, c)
+			}
+			if samples[i][0] == samples[j][0] && samples[i][1] == samples[j][1] {
+				t.Errorf("generators %v and %v produce the same stream", i, j)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "math/bits"
+
+const (
+	primeRounds = 8
+)
+
+// ProbablePrime returns a pseudo-random odd number of the given bit length that passes
+// several rounds of the Miller-Rabin test with pseudo-random bases. The result is prime with
+// very high probability, but this is not guaranteed. ProbablePrime is not suitable for
+// cryptographic use. It panics if bits < 2 or bits > 64.
+func (r *Rand) ProbablePrime(bits int) uint64 {
+	if bits < 2 || bits > 64 {
+		panic("invalid argument to ProbablePrime")
+	}
+	top := uint64(1) << (bits - 1)
+	for {
+		n := top | r.Uint64n(top) | 1
+		if r.probablyPrime(n) {
+			return n
+		}
+	}
+}
+
+// probablyPrime performs the Miller-Rabin test of odd n > 1.
+func (r *Rand) probablyPrime(n uint64) bool {
+	if n < 4 {
+		return true
+	}
+	for _, p := range [...]uint64{3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37} {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+	d := n - 1
+	s := bits.TrailingZeros64(d)
+	d >>= s
+	for i := 0; i < primeRounds; i++ {
+		a := 2 + r.Uint64n(n-3) // [2, n-2]
+		x := powMod(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		composite := true
+		for j := 1; j < s; j++ {
+			x = mulMod(x, x, n)
+			if x == n-1 {
+				composite = false
+				break
+			}
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+func powMod(a, e, m uint64) uint64 {
+	res := uint64(1)
+	for ; e > 0; e >>= 1 {
+		if e&1 != 0 {
+			res = mulMod(res, a, m)
+		}
+		a = mulMod(a, a, m)
+	}
+	return res
+}
@@ -0,0 +1,30 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math/big"
+	"math/bits"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+func TestRand_ProbablePrime(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(2, 64).Draw(t, "bits").(int)
+		p := r.ProbablePrime(n)
+		if l := bits.Len64(p); l != n {
+			t.Fatalf("got %v with bit length %v instead of %v", p, l, n)
+		}
+		if !new(big.Int).SetUint64(p).ProbablyPrime(20) {
+			t.Fatalf("got composite %v", p)
+		}
+	})
+}
@@ -19,13 +19,20 @@
 // than the [math/rand] package. However, this package's outputs might be
 // predictable regardless of how it's seeded. For random numbers
 // suitable for security-sensitive work, see the [crypto/rand] package.
+//
+// For a given seed, methods that do not depend on the size of int, such as [Rand.Uint64n],
+// [Rand.Int63n], [Rand.Perm] and [Rand.Shuffle], produce the same values on all platforms.
+// [Rand.Int] and [Rand.Intn] do not: their results differ between 32-bit and 64-bit platforms;
+// use [Rand.Int63] and [Rand.Int63n] instead when cross-platform reproducibility is required.
 package rand
 
 import (
 	"encoding/binary"
+	"errors"
 	"io"
 	"math"
 	"math/bits"
+	mathrand "math/rand"
 )
 
 const (
@@ -50,10 +57,22 @@ const (
 // [SFC64]: http://pracrand.sourceforge.net/RNG_engines.txt
 type Rand struct {
 	sfc64
+	val    uint64
+	pos    int
+	unread readState
+}
+
+// readState is the state of the read buffer before the last ReadByte call.
+type readState struct {
 	val uint64
-	pos int
+	w   uint64
+	pos int // 0 if there is no byte to unread
 }
 
+var errZeroState = errors.New("rand: all-zero generator state")
+
+var errUnreadByte = errors.New("rand: UnreadByte: previous operation was not a ReadByte")
+
 // New returns an initialized generator. If seed is empty, generator is initialized to a non-deterministic state.
 // Otherwise, generator is seeded with the values from seed. New panics if len(seed) > 3.
 func New(seed ...uint64) *Rand {
@@ -77,14 +96,120 @@ func (r *Rand) new_(seed ...uint64) {
 	}
 }
 
+// FromStdSource returns a generator seeded with values pulled from s, to ease migration from [math/rand].
+// The returned generator does not share state with s: its output is unrelated to the output of s,
+// and the state of s can not be recovered from it.
+func FromStdSource(s mathrand.Source) *Rand {
+	var seed [3]uint64
+	if s64, ok := s.(mathrand.Source64); ok {
+		for i := range seed {
+			seed[i] = s64.Uint64()
+		}
+	} else {
+		for i := range seed {
+			seed[i] = uint64(s.Int63())>>31 | uint64(s.Int63())<<32
+		}
+	}
+	var r Rand
+	r.init3(seed[0], seed[1], seed[2])
+	return &r
+}
+
+// NewFromString returns a generator deterministically seeded from key. The key is absorbed
+// 8 bytes (little-endian, zero-padded) at a time into the state of a generator seeded with len(key),
+// by XORing each word into the first state variable and advancing the generator;
+// three outputs of that generator are then used as the seed of the result. The same key
+// always yields the same generator, regardless of the process, machine or architecture.
+func NewFromString(key string) *Rand {
+	var s sfc64
+	s.init1(uint64(len(key)))
+	for len(key) > 0 {
+		var buf [8]byte
+		n := copy(buf[:], key)
+		s.a ^= binary.LittleEndian.Uint64(buf[:])
+		s.next64()
+		key = key[n:]
+	}
+	var r Rand
+	r.init3(s.next64(), s.next64(), s.next64())
+	return &r
+}
+
+// Substreams returns count new generators, each seeded with three 64-bit values drawn from r,
+// so that every component of a simulation can get its own reproducible stream. The substreams
+// depend only on the state of r, which is advanced by 3*count values. It panics if count < 0.
+func (r *Rand) Substreams(count int) []*Rand {
+	if count < 0 {
+		panic("invalid argument to Substreams")
+	}
+	s := make([]Rand, count)
+	p := make([]*Rand, count)
+	for i := range s {
+		s[i].init3(r.next64(), r.next64(), r.next64())
+		p[i] = &s[i]
+	}
+	return p
+}
+
+// ForKey returns a new generator whose state is derived from the current state of r and key.
+// ForKey does not advance r, so as long as r is not used in between, the generators returned
+// for each key are the same regardless of the order in which the keys are processed, which makes
+// it suitable for order-independent or parallel generation of per-key data.
+func (r *Rand) ForKey(key uint64) *Rand {
+	var s Rand
+	s.init3(r.a^hash64(key, r.w), r.b^hash64(key, r.w+1), r.c^hash64(key, r.w+2))
+	return &s
+}
+
 // Seed uses the provided seed value to initialize the generator to a deterministic state.
+// Every seed, including 0, is safe: the state is mixed by advancing the generator, and since
+// the counter word is part of the state, the generator has no degenerate fixed points or short cycles.
 func (r *Rand) Seed(seed uint64) {
 	r.init1(seed)
 	r.val = 0
 	r.pos = 0
+	r.unread = readState{}
+}
+
+// SeedState sets the state of the generator to a, b and c, with the counter word reset to 1,
+// giving access to all 2^192 - 1 non-zero states. Unlike [Rand.Seed] and [New], it does not mix
+// the seed values by advancing the generator, so seeds with low entropy, like mostly zero bits,
+// produce visibly non-random output for the first few dozen values; avoid them, or use [New]
+// with three seed values instead. SeedState panics if a, b and c are all zero,
+// which starts the generator with a counting sequence; see [Rand.TrySeedState].
+func (r *Rand) SeedState(a, b, c uint64) {
+	if err := r.TrySeedState(a, b, c); err != nil {
+		panic("invalid argument to SeedState")
+	}
+}
+
+// TrySeedState is like [Rand.SeedState], but returns an error instead of panicking
+// if a, b and c are all zero, leaving the generator unchanged.
+func (r *Rand) TrySeedState(a, b, c uint64) error {
+	if a|b|c == 0 {
+		return errZeroState
+	}
+	r.a, r.b, r.c, r.w = a, b, c, 1
+	r.val = 0
+	r.pos = 0
+	r.unread = readState{}
+	return nil
+}
+
+// Mix folds entropy into the state of the generator, for example to periodically reseed
+// a long-running generator from an external source. Unlike [Rand.Seed], it keeps the existing state,
+// so the result depends on both the previous seed and all mixed values. Mix changes the entire future
+// sequence of the generator, except for bytes already buffered by [Rand.Read] and 32-bit methods.
+func (r *Rand) Mix(entropy uint64) {
+	r.a ^= entropy
+	for i := 0; i < 12; i++ {
+		r.next64()
+	}
 }
 
 // MarshalBinary returns the binary representation of the current state of the generator.
+// Restoring it with [Rand.UnmarshalBinary] makes every method of the generator repeat its results
+// exactly, so saving the state before a failing run is enough to replay the run deterministically.
 func (r *Rand) MarshalBinary() ([]byte, error) {
 	var data [randSizeof]byte
 	r.marshalBinary(&data)
@@ -111,6 +236,7 @@ func (r *Rand) UnmarshalBinary(data []byte) error {
 	r.w = binary.LittleEndian.Uint64(data[24:])
 	r.val = binary.LittleEndian.Uint64(data[32:])
 	r.pos = int(data[40])
+	r.unread = readState{}
 	return nil
 }
 
@@ -124,7 +250,30 @@ func (r *Rand) Float64() float64 {
 	return float64(r.next64()&int53Mask) * f53Mul
 }
 
+// float64Pos returns a uniformly distributed pseudo-random number in the open interval (0.0, 1.0).
+func (r *Rand) float64Pos() float64 {
+	return (float64(r.next64()&int53Mask) + 0.5) * f53Mul
+}
+
+// Float64Open returns, as a float64, a pseudo-random number in the open interval (lo, hi),
+// distributed approximately uniformly. Draws that round to either endpoint are rejected.
+// It panics if lo and hi are not finite, or if there is no float64 strictly between them.
+func (r *Rand) Float64Open(lo, hi float64) float64 {
+	if !(lo < hi) || math.IsInf(lo, 0) || math.IsInf(hi, 0) || math.Nextafter(lo, hi) == hi {
+		panic("invalid argument to Float64Open")
+	}
+	for {
+		// the weighted sum avoids overflow of hi-lo
+		u := r.float64Pos()
+		x := lo*(1-u) + hi*u
+		if lo < x && x < hi {
+			return x
+		}
+	}
+}
+
 // Int returns a uniformly distributed non-negative pseudo-random int.
+// Its results depend on the size of int; see [Rand.Int63] for a portable alternative.
 func (r *Rand) Int() int {
 	return int(r.next64() & intMask)
 }
@@ -159,6 +308,7 @@ func (r *Rand) Int63n(n int64) int64 {
 
 // Intn returns, as an int, a uniformly distributed non-negative pseudo-random number
 // in the half-open interval [0, n). It panics if n <= 0.
+// Its results depend on the size of int; see [Rand.Int63n] for a portable alternative.
 func (r *Rand) Intn(n int) int {
 	if n <= 0 {
 		panic("invalid argument to Intn")
@@ -170,7 +320,21 @@ func (r *Rand) Intn(n int) int {
 	}
 }
 
+// IntnExcept returns, as an int, a uniformly distributed non-negative pseudo-random number
+// in the half-open interval [0, n) that is not equal to except. It panics if n <= 1 or except is outside of [0, n).
+func (r *Rand) IntnExcept(n, except int) int {
+	if n <= 1 || except < 0 || except >= n {
+		panic("invalid argument to IntnExcept")
+	}
+	v := r.Intn(n - 1)
+	if v >= except {
+		v++
+	}
+	return v
+}
+
 // Perm returns, as a slice of n ints, a pseudo-random permutation of the integers in the half-open interval [0, n).
+// Its results do not depend on the size of int.
 func (r *Rand) Perm(n int) []int {
 	p := make([]int, n)
 	r.perm(p)
@@ -218,6 +382,39 @@ func (r *Rand) Read(p []byte) (n int, err error) {
 	return
 }
 
+// ReadByte returns a single pseudo-random byte. It always returns a nil error.
+// Together with [Rand.Read], it produces the same stream of bytes as a single Read call would.
+func (r *Rand) ReadByte() (byte, error) {
+	if r.pos == 0 {
+		r.val, r.pos = r.next64(), 8
+	}
+	r.unread = readState{val: r.val, w: r.w, pos: r.pos}
+	b := byte(r.val)
+	r.val >>= 8
+	r.pos--
+	return b, nil
+}
+
+// UnreadByte returns the byte returned by the last [Rand.ReadByte] call to the read buffer,
+// so that it is returned again by the next read. It returns an error if the generator
+// was used in any other way since the last ReadByte call.
+func (r *Rand) UnreadByte() error {
+	u := r.unread
+	if u.pos == 0 || u.w != r.w || u.pos != r.pos+1 {
+		return errUnreadByte
+	}
+	r.val, r.pos = u.val, u.pos
+	r.unread = readState{}
+	return nil
+}
+
+// BufferedBytes returns the number of pseudo-random bytes (from 0 to 7) that are left over
+// from the last partially consumed 64-bit value and will be returned by subsequent [Rand.Read]
+// calls before the generator is advanced.
+func (r *Rand) BufferedBytes() int {
+	return r.pos
+}
+
 // Shuffle pseudo-randomizes the order of elements. n is the number of elements. Shuffle panics if n < 0.
 // swap swaps the elements with indexes i and j.
 //
@@ -237,6 +434,17 @@ func (r *Rand) Shuffle(n int, swap func(i, j int)) {
 	}
 }
 
+// ShuffleKeyed is like [Rand.Shuffle], but the order depends only on n and key: it shuffles
+// using a fresh generator seeded with key, and neither uses nor advances r. It panics if n < 0.
+func (r *Rand) ShuffleKeyed(n int, key uint64, swap func(i, j int)) {
+	if n < 0 {
+		panic("invalid argument to ShuffleKeyed")
+	}
+	var s Rand
+	s.init1(key)
+	s.Shuffle(n, swap)
+}
+
 // Uint32 returns a uniformly distributed pseudo-random 32-bit value as an uint32.
 func (r *Rand) Uint32() uint32 {
 	return uint32(r.next32())
@@ -271,6 +479,26 @@ func (r *Rand) Uint64() uint64 {
 	return r.next64()
 }
 
+// Peek returns the value that [Rand.Uint64] would return after n more calls to it,
+// without changing the state of the generator. Peek(0) returns the value of the next Uint64 call.
+// Peek takes O(n) time.
+func (r *Rand) Peek(n uint64) uint64 {
+	s := r.sfc64
+	for ; n > 0; n-- {
+		s.next64()
+	}
+	return s.next64()
+}
+
+// Position returns the counter word of the generator state, which is incremented once
+// for every 64 bits of output the generator produces. Comparing positions shows how much
+// output was consumed between two points, or whether two code paths consumed the same amount.
+// Position only reflects the counter, not the rest of the state, so equal positions do not imply
+// equal states. It also does not account for bytes buffered by [Rand.Read] and 32-bit methods.
+func (r *Rand) Position() uint64 {
+	return r.w
+}
+
 // Uint64n returns, as an uint64, a uniformly distributed pseudo-random number in [0, n). Uint64n(0) returns 0.
 func (r *Rand) Uint64n(n uint64) uint64 {
 	// "An optimal algorithm for bounded random integers" by Stephen Canon, https://github.com/apple/swift/pull/39143
@@ -284,3 +512,15 @@ func (r *Rand) Uint64n(n uint64) uint64 {
 	_, carry := bits.Add64(frac, hi, 0)
 	return res + carry
 }
+
+// RandomSetBit returns the index of a uniformly chosen set bit of mask, or -1 if mask is zero.
+func (r *Rand) RandomSetBit(mask uint64) int {
+	if mask == 0 {
+		return -1
+	}
+	k := r.Uint32n(uint32(bits.OnesCount64(mask)))
+	for ; k > 0; k-- {
+		mask &= mask - 1 // clear the lowest set bit
+	}
+	return bits.TrailingZeros64(mask)
+}
@@ -17,3 +17,17 @@ func GetExponentialDistributionParameters() (float64, [256]uint64, [256]float64,
 }
 
 var ShuffleSliceGeneric func(*Rand, []int)
+
+// SetSobolIndexForTest moves s to the n-th point of the sequence.
+func SetSobolIndexForTest(s *Sobol, n uint64) {
+	g := n ^ n>>1
+	for d := range s.x {
+		s.x[d] = 0
+		for i := 0; i < sobolBits; i++ {
+			if g>>i&1 != 0 {
+				s.x[d] ^= s.v[d][i]
+			}
+		}
+	}
+	s.n = n
+}
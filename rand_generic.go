@@ -8,7 +8,11 @@
 
 package rand
 
-import "math"
+import (
+	"errors"
+	"math"
+	"sort"
+)
 
 // ShuffleSlice pseudo-randomizes the order of the elements of s.
 //
@@ -37,3 +41,156 @@ func ShuffleSlice[S ~[]E, E any](r *Rand, s S) {
 		}
 	}
 }
+
+// WeightedShuffle returns a copy of items in a pseudo-random order, where items with higher
+// weights tend to appear earlier. The order is produced by sorting the items by the keys
+// U^(1/w) in descending order (Efraimidis-Spirakis), where U is uniform in (0, 1) and w is the weight
+// of the item; items with zero weight are placed last. WeightedShuffle returns an error
+// if the lengths of items and weights differ or if any weight is negative or not finite.
+//
+// When r is nil, WeightedShuffle uses non-deterministic goroutine-local
+// pseudo-random data source, and is safe for concurrent use from multiple goroutines.
+func WeightedShuffle[T any](r *Rand, items []T, weights []float64) ([]T, error) {
+	if len(items) != len(weights) {
+		return nil, errors.New("rand: items and weights differ in length")
+	}
+	for _, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			return nil, errors.New("rand: weights must be finite and non-negative")
+		}
+	}
+	k, zero := weightedKeysOf(r, weights)
+	sort.Stable(k)
+	res := make([]T, 0, len(items))
+	for _, key := range k {
+		res = append(res, items[key.index])
+	}
+	for _, i := range zero {
+		res = append(res, items[i])
+	}
+	return res, nil
+}
+
+// weightedKeysOf returns the Efraimidis-Spirakis keys of the indices with positive weights,
+// and the indices with zero weights separately, in increasing order.
+// When r is nil, weightedKeysOf uses non-deterministic goroutine-local pseudo-random data source.
+func weightedKeysOf(r *Rand, weights []float64) (weightedKeys, []int) {
+	k := make(weightedKeys, 0, len(weights))
+	var zero []int
+	for i, w := range weights {
+		if w == 0 {
+			zero = append(zero, i)
+			continue
+		}
+		var u float64
+		if r == nil {
+			u = float64Pos()
+		} else {
+			u = r.float64Pos()
+		}
+		// log(w) - log(-log(U)) orders the keys the same way as U^(1/w), but is finite
+		// for U in (0, 1) and any positive w, including subnormal ones
+		k = append(k, weightedKey{i, math.Log(w) - math.Log(-math.Log(u))})
+	}
+	return k, zero
+}
+
+type weightedKey struct {
+	index int
+	key   float64
+}
+
+type weightedKeys []weightedKey
+
+func (k weightedKeys) Len() int           { return len(k) }
+func (k weightedKeys) Less(i, j int) bool { return k[i].key > k[j].key }
+func (k weightedKeys) Swap(i, j int)      { k[i], k[j] = k[j], k[i] }
+
+// PickN returns count elements of s, chosen uniformly and independently (with replacement).
+// It panics if count < 0, or if s is empty and count > 0.
+//
+// When r is nil, PickN uses non-deterministic goroutine-local
+// pseudo-random data source, and is safe for concurrent use from multiple goroutines.
+func PickN[T any](r *Rand, s []T, count int) []T {
+	if count < 0 || (len(s) == 0 && count > 0) {
+		panic("invalid argument to PickN")
+	}
+	res := make([]T, count)
+	for i := range res {
+		if r == nil {
+			res[i] = s[Uint64n(uint64(len(s)))]
+		} else {
+			res[i] = s[r.Uint64n(uint64(len(s)))]
+		}
+	}
+	return res
+}
+
+// ShuffleParallel pseudo-randomizes the order of the elements of a and b,
+// applying the same permutation to both. It panics if len(a) != len(b).
+//
+// When r is nil, ShuffleParallel uses non-deterministic goroutine-local
+// pseudo-random data source, and is safe for concurrent use from multiple goroutines.
+func ShuffleParallel[A, B any](r *Rand, a []A, b []B) {
+	if len(a) != len(b) {
+		panic("invalid argument to ShuffleParallel")
+	}
+	if r == nil {
+		i := len(a) - 1
+		for ; i > math.MaxInt32-1; i-- {
+			j := int(Uint64n(uint64(i) + 1))
+			a[i], a[j] = a[j], a[i]
+			b[i], b[j] = b[j], b[i]
+		}
+		for ; i > 0; i-- {
+			j := int(Uint32n(uint32(i) + 1))
+			a[i], a[j] = a[j], a[i]
+			b[i], b[j] = b[j], b[i]
+		}
+	} else {
+		i := len(a) - 1
+		for ; i > math.MaxInt32-1; i-- {
+			j := int(r.Uint64n(uint64(i) + 1))
+			a[i], a[j] = a[j], a[i]
+			b[i], b[j] = b[j], b[i]
+		}
+		for ; i > 0; i-- {
+			j := int(r.Uint32n(uint32(i) + 1))
+			a[i], a[j] = a[j], a[i]
+			b[i], b[j] = b[j], b[i]
+		}
+	}
+}
+
+// ChooseEnum returns a uniformly chosen element of values, preserving its named type.
+// It panics if values is empty.
+//
+// When r is nil, ChooseEnum uses non-deterministic goroutine-local
+// pseudo-random data source, and is safe for concurrent use from multiple goroutines.
+func ChooseEnum[T ~int](r *Rand, values []T) T {
+	if len(values) == 0 {
+		panic("invalid argument to ChooseEnum")
+	}
+	if r == nil {
+		return values[Uint64n(uint64(len(values)))]
+	}
+	return values[r.Uint64n(uint64(len(values)))]
+}
+
+// ChooseWithIndex returns a uniformly chosen element of s together with its index.
+// It panics if s is empty.
+//
+// When r is nil, ChooseWithIndex uses non-deterministic goroutine-local
+// pseudo-random data source, and is safe for concurrent use from multiple goroutines.
+func ChooseWithIndex[T any](r *Rand, s []T) (T, int) {
+	if len(s) == 0 {
+		panic("invalid argument to ChooseWithIndex")
+	}
+	var i int
+	if r == nil {
+		i = int(Uint64n(uint64(len(s))))
+	} else {
+		i = int(r.Uint64n(uint64(len(s))))
+	}
+	return s[i], i
+}
@@ -10,6 +10,8 @@ package rand_test
 
 import (
 	"bytes"
+	"fmt"
+	"math"
 	"pgregory.net/rand"
 	"pgregory.net/rapid"
 	"testing"
@@ -42,3 +44,206 @@ func TestShuffleSlice(t *testing.T) {
 		}
 	})
 }
+
+func TestWeightedShuffle(t *testing.T) {
+	const (
+		n = 10
+		N = 10000
+	)
+	r := rand.New(1)
+	items := make([]int, n)
+	weights := make([]float64, n)
+	for i := range items {
+		items[i] = i
+		weights[i] = float64(i + 1)
+	}
+	var ranks [n]float64
+	for k := 0; k < N; k++ {
+		s, err := rand.WeightedShuffle(r, items, weights)
+		if err != nil {
+			t.Fatalf("got unexpected error: %v", err)
+		}
+		seen := make([]bool, n)
+		for rank, v := range s {
+			if seen[v] {
+				t.Fatalf("%v is not a permutation of %v", s, items)
+			}
+			seen[v] = true
+			ranks[v] += float64(rank) / N
+		}
+	}
+	for i := 1; i < n; i++ {
+		if ranks[i] >= ranks[i-1] {
+			t.Errorf("average rank %v of item with weight %v is not lower than %v of item with weight %v", ranks[i], weights[i], ranks[i-1], weights[i-1])
+		}
+	}
+}
+
+func TestWeightedShuffle_Nil(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		s, err := rand.WeightedShuffle(nil, []int{0, 1, 2, 3}, []float64{0, 1, 2, 0})
+		if err != nil {
+			t.Fatalf("got unexpected error: %v", err)
+		}
+		if len(s) != 4 || s[0]+s[1] != 3 || s[2] != 0 || s[3] != 3 {
+			t.Fatalf("got %v instead of a permutation with zero-weight items last", s)
+		}
+	}
+}
+
+func TestWeightedShuffle_Invalid(t *testing.T) {
+	r := rand.New(1)
+	if _, err := rand.WeightedShuffle(r, []int{1, 2}, []float64{1}); err == nil {
+		t.Errorf("no error for mismatched lengths")
+	}
+	if _, err := rand.WeightedShuffle(r, []int{1, 2}, []float64{1, -1}); err == nil {
+		t.Errorf("no error for negative weight")
+	}
+}
+
+func TestPickN(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(1, small).Draw(t, "n").(int)
+		count := rapid.IntRange(0, small).Draw(t, "count").(int)
+		src := make([]int, n)
+		for i := range src {
+			src[i] = i * 3
+		}
+		res := rand.PickN(r, src, count)
+		if len(res) != count {
+			t.Fatalf("got %v elements instead of %v", len(res), count)
+		}
+		for _, v := range res {
+			if v%3 != 0 || v < 0 || v/3 >= n {
+				t.Fatalf("got %v not from %v", v, src)
+			}
+		}
+	})
+}
+
+func TestShuffleParallel(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		a := make([]int, n)
+		b := make([]string, n)
+		for i := range a {
+			a[i] = i
+			b[i] = fmt.Sprint(i)
+		}
+		rand.ShuffleParallel(r, a, b)
+		seen := make([]bool, n)
+		for i := range a {
+			if b[i] != fmt.Sprint(a[i]) {
+				t.Fatalf("elements %v and %q are not paired after shuffle", a[i], b[i])
+			}
+			if seen[a[i]] {
+				t.Fatalf("%v is not a permutation", a)
+			}
+			seen[a[i]] = true
+		}
+		r.Seed(s)
+		c := make([]int, n)
+		for i := range c {
+			c[i] = i
+		}
+		rand.ShuffleSlice(r, c)
+		for i := range c {
+			if a[i] != c[i] {
+				t.Fatalf("ShuffleParallel order %v differs from ShuffleSlice order %v", a, c)
+			}
+		}
+	})
+}
+
+type weekday int
+
+const (
+	monday weekday = iota
+	tuesday
+	wednesday
+)
+
+func TestChooseEnum(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		values := []weekday{monday, tuesday, wednesday}
+		var d weekday = rand.ChooseEnum(r, values)
+		if d != monday && d != tuesday && d != wednesday {
+			t.Fatalf("got %v which is not one of %v", d, values)
+		}
+		if d := rand.ChooseEnum(nil, values[1:2]); d != tuesday {
+			t.Fatalf("got %v instead of the only value %v", d, tuesday)
+		}
+	})
+}
+
+func TestChooseWithIndex(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(1, small).Draw(t, "n").(int)
+		a := make([]string, n)
+		for i := range a {
+			a[i] = fmt.Sprint(i)
+		}
+		v, i := rand.ChooseWithIndex(r, a)
+		if i < 0 || i >= n || v != a[i] {
+			t.Fatalf("got %q at index %v of %v", v, i, a)
+		}
+	})
+}
+
+func TestChooseWithIndex_Uniform(t *testing.T) {
+	const (
+		n = 7
+		N = 70000
+	)
+	r := rand.New(1)
+	s := make([]int, n)
+	var counts [n]int
+	for i := 0; i < N; i++ {
+		_, j := rand.ChooseWithIndex(r, s)
+		counts[j]++
+	}
+	want := float64(N) / n
+	for i, c := range counts {
+		if math.Abs(float64(c)-want) > 5*math.Sqrt(want) {
+			t.Errorf("index %v chosen %v times instead of ~%v", i, c, want)
+		}
+	}
+}
+
+func TestWeightedShuffle_Zero(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		items := make([]int, n)
+		weights := make([]float64, n)
+		for i := range items {
+			items[i] = i
+			switch rapid.IntRange(0, 2).Draw(t, "kind").(int) {
+			case 1:
+				weights[i] = math.SmallestNonzeroFloat64 * float64(rapid.IntRange(1, 100).Draw(t, "tiny").(int))
+			case 2:
+				weights[i] = rapid.Float64Range(1e-300, 10).Draw(t, "w").(float64)
+			}
+		}
+		res, err := rand.WeightedShuffle(r, items, weights)
+		if err != nil {
+			t.Fatalf("got unexpected error: %v", err)
+		}
+		zero := false
+		for _, i := range res {
+			if weights[i] > 0 && zero {
+				t.Fatalf("got item %v with weight %v after an item with zero weight", i, weights[i])
+			}
+			zero = weights[i] == 0
+		}
+	})
+}
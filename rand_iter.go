@@ -0,0 +1,86 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build go1.23
+
+package rand
+
+import (
+	"container/heap"
+	"iter"
+	"math"
+)
+
+// Permutation returns an iterator over a pseudo-random permutation of the integers in the half-open
+// interval [0, n), produced lazily by a forward Fisher-Yates shuffle of a scratch slice, so that
+// stopping early saves the work for the remaining elements. Every iteration draws from r and yields
+// a new permutation. It panics if n < 0.
+func (r *Rand) Permutation(n int) iter.Seq[int] {
+	if n < 0 {
+		panic("invalid argument to Permutation")
+	}
+	return func(yield func(int) bool) {
+		p := make([]int, n)
+		for i := range p {
+			p[i] = i
+		}
+		for i := range p {
+			j := i + int(r.Uint64n(uint64(n-i)))
+			p[i], p[j] = p[j], p[i]
+			if !yield(p[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Samples returns an infinite iterator over values drawn by calling dist with r, such as
+// (*Rand).Float64, so that for x := range r.Samples(f) sees the same values as repeated calls to f(r).
+// The iterator never stops by itself: the loop over it must be exited with break or return.
+func (r *Rand) Samples(dist func(*Rand) float64) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		for yield(dist(r)) {
+		}
+	}
+}
+
+// WeightedOrder returns an iterator over the indices of weights in a pseudo-random order, where indices
+// with higher weights tend to appear earlier, as in [WeightedShuffle]: each index comes next with probability
+// proportional to its weight among the indices not yielded yet, and indices with zero weight come last,
+// in increasing order.
+// The Efraimidis-Spirakis keys are kept in a heap, so that the order is produced lazily and stopping
+// early saves the work for the remaining indices. Every iteration draws from r and yields a new order.
+// It panics if any weight is negative or not finite.
+func (r *Rand) WeightedOrder(weights []float64) iter.Seq[int] {
+	for _, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			panic("invalid argument to WeightedOrder")
+		}
+	}
+	return func(yield func(int) bool) {
+		k, zero := weightedKeysOf(r, weights)
+		heap.Init(&k)
+		for len(k) > 0 {
+			if !yield(heap.Pop(&k).(weightedKey).index) {
+				return
+			}
+		}
+		for _, i := range zero {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func (k *weightedKeys) Push(x interface{}) { *k = append(*k, x.(weightedKey)) }
+
+func (k *weightedKeys) Pop() interface{} {
+	old := *k
+	x := old[len(old)-1]
+	*k = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,135 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build go1.23
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+func TestRand_Permutation(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		var p []int
+		for i := range r.Permutation(n) {
+			p = append(p, i)
+		}
+		if len(p) != n || !isPerm(p) {
+			t.Fatalf("got %v which is not a permutation of %v elements", p, n)
+		}
+	})
+}
+
+func TestRand_Permutation_Break(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(1, small).Draw(t, "n").(int)
+		k := rapid.IntRange(0, n-1).Draw(t, "k").(int)
+		seq := r.Permutation(n)
+		var prefix []int
+		for i := range seq {
+			if len(prefix) == k {
+				break
+			}
+			prefix = append(prefix, i)
+		}
+		seen := make([]bool, n)
+		for _, i := range prefix {
+			if seen[i] {
+				t.Fatalf("got repeated value %v in %v", i, prefix)
+			}
+			seen[i] = true
+		}
+		// the sequence is still usable after an early break
+		var p []int
+		for i := range seq {
+			p = append(p, i)
+		}
+		if !isPerm(p) {
+			t.Fatalf("got %v which is not a permutation after an early break", p)
+		}
+	})
+}
+
+func TestRand_Samples(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		k := rapid.IntRange(0, small).Draw(t, "k").(int)
+		r1, r2 := rand.New(s), rand.New(s)
+		i := 0
+		for x := range r1.Samples((*rand.Rand).NormFloat64) {
+			if i == k {
+				break
+			}
+			if y := r2.NormFloat64(); x != y {
+				t.Fatalf("got %v at %v instead of %v", x, i, y)
+			}
+			i++
+		}
+		if i != k {
+			t.Fatalf("got %v samples instead of %v", i, k)
+		}
+	})
+}
+
+func TestRand_WeightedOrder(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		weights := make([]float64, rapid.IntRange(0, small).Draw(t, "n").(int))
+		for i := range weights {
+			switch rapid.IntRange(0, 3).Draw(t, "kind").(int) {
+			case 1:
+				weights[i] = math.SmallestNonzeroFloat64 * float64(rapid.IntRange(1, 100).Draw(t, "tiny").(int))
+			case 2, 3:
+				weights[i] = rapid.Float64Range(0, 10).Draw(t, "w").(float64)
+			}
+		}
+		var p []int
+		zero := false
+		for i := range r.WeightedOrder(weights) {
+			if weights[i] > 0 && zero {
+				t.Fatalf("got index %v with positive weight after an index with zero weight", i)
+			}
+			zero = weights[i] == 0
+			p = append(p, i)
+		}
+		if len(p) != len(weights) || !isPerm(p) {
+			t.Fatalf("got %v which is not a permutation of %v elements", p, len(weights))
+		}
+	})
+}
+
+func TestRand_WeightedOrder_First(t *testing.T) {
+	const N = 100000
+	weights := []float64{1, 10, 0, 3, 0.5, 5.5}
+	sum := 0.0
+	for _, w := range weights {
+		sum += w
+	}
+	r := rand.New(1)
+	counts := make([]int, len(weights))
+	for i := 0; i < N; i++ {
+		for j := range r.WeightedOrder(weights) {
+			counts[j]++
+			break
+		}
+	}
+	for i, c := range counts {
+		p := weights[i] / sum
+		if want := N * p; math.Abs(float64(c)-want) > 5*math.Sqrt(N*p*(1-p)) {
+			t.Errorf("index %v came first %v times instead of ~%v", i, c, want)
+		}
+	}
+}
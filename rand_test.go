@@ -8,8 +8,11 @@ package rand_test
 
 import (
 	"bytes"
+	"fmt"
+	"io"
 	"math"
 	"math/bits"
+	mathrand "math/rand"
 	"pgregory.net/rand"
 	"pgregory.net/rapid"
 	"testing"
@@ -57,6 +60,38 @@ func TestRand_Float64(t *testing.T) {
 	})
 }
 
+func TestRand_Float64Open(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		lo := rapid.Float64Range(-math.MaxFloat64, math.MaxFloat64).Draw(t, "lo").(float64)
+		hi := rapid.Float64Range(lo, math.MaxFloat64).Draw(t, "hi").(float64)
+		if !(lo < hi) || math.IsInf(hi, 0) || math.Nextafter(lo, hi) == hi {
+			return
+		}
+		if f := r.Float64Open(lo, hi); !(lo < f && f < hi) {
+			t.Fatalf("got %v outside of (%v, %v)", f, lo, hi)
+		}
+	})
+}
+
+func TestRand_Float64Open_Endpoints(t *testing.T) {
+	r := rand.New(1)
+	for _, c := range []struct{ lo, hi float64 }{
+		{0, 1},
+		{1, 1 + 0x1p-50},
+		{1, math.Nextafter(math.Nextafter(1, 2), 2)},
+		{-math.MaxFloat64, math.MaxFloat64},
+		{0, math.SmallestNonzeroFloat64 * 3},
+	} {
+		for i := 0; i < 1000000; i++ {
+			if f := r.Float64Open(c.lo, c.hi); !(c.lo < f && f < c.hi) {
+				t.Fatalf("got %v outside of (%v, %v)", f, c.lo, c.hi)
+			}
+		}
+	}
+}
+
 func TestRand_Int31n(t *testing.T) {
 	rapid.Check(t, func(t *rapid.T) {
 		s := rapid.Uint64().Draw(t, "s").(uint64)
@@ -93,6 +128,19 @@ func TestRand_Intn(t *testing.T) {
 	})
 }
 
+func TestRand_Perm_Portable(t *testing.T) {
+	// Perm and Shuffle must produce the same results on 32-bit and 64-bit platforms
+	r := rand.New(1)
+	if p, want := fmt.Sprint(r.Perm(10)), "[9 8 1 5 2 7 6 3 0 4]"; p != want {
+		t.Errorf("got Perm(10) %v instead of %v", p, want)
+	}
+	s := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	r.Shuffle(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+	if p, want := fmt.Sprint(s), "[0 7 6 8 9 3 4 5 2 1]"; p != want {
+		t.Errorf("got shuffled %v instead of %v", p, want)
+	}
+}
+
 func TestRand_Uint32n(t *testing.T) {
 	rapid.Check(t, func(t *rapid.T) {
 		s := rapid.Uint64().Draw(t, "s").(uint64)
@@ -140,6 +188,186 @@ func TestRand_MarshalBinary_Roundtrip(t *testing.T) {
 	})
 }
 
+func TestRand_MarshalBinary_Replay(t *testing.T) {
+	// a function under test that uses a variety of methods, including buffered ones
+	scenario := func(r *rand.Rand) []float64 {
+		var out []float64
+		for i := 0; i < 10; i++ {
+			b, _ := r.ReadByte()
+			out = append(out, r.Float64(), float64(r.Intn(1000)), r.NormFloat64(), float64(r.Uint32()), float64(b))
+		}
+		for _, v := range r.Perm(10) {
+			out = append(out, float64(v))
+		}
+		return out
+	}
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		r.Uint32() // start with a partially consumed buffer
+		data, err := r.MarshalBinary()
+		if err != nil {
+			t.Fatalf("got unexpected marshal error: %v", err)
+		}
+		want := scenario(r)
+		var replay rand.Rand
+		if err := replay.UnmarshalBinary(data); err != nil {
+			t.Fatalf("got unexpected unmarshal error: %v", err)
+		}
+		got := scenario(&replay)
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("got %v at %v instead of %v", got[i], i, want[i])
+			}
+		}
+	})
+}
+
+func TestRand_SeedState(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		var seed [2][3]uint64
+		for i := range seed {
+			for j := range seed[i] {
+				seed[i][j] = rapid.Uint64().Draw(t, "seed").(uint64)
+			}
+		}
+		if seed[0] == [3]uint64{} || seed[1] == [3]uint64{} {
+			return
+		}
+		r1, r2 := rand.New(), rand.New()
+		r1.SeedState(seed[0][0], seed[0][1], seed[0][2])
+		r2.SeedState(seed[1][0], seed[1][1], seed[1][2])
+		data, err := r1.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var r3 rand.Rand
+		if err := r3.UnmarshalBinary(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		same := true
+		for i := 0; i < 10; i++ {
+			u1, u2, u3 := r1.Uint64(), r2.Uint64(), r3.Uint64()
+			if u1 != u3 {
+				t.Fatalf("got %v after unmarshaling instead of %v", u3, u1)
+			}
+			same = same && u1 == u2
+		}
+		if same && seed[0] != seed[1] {
+			t.Fatalf("seeds %v and %v produce identical streams", seed[0], seed[1])
+		}
+	})
+}
+
+func TestRand_TrySeedState(t *testing.T) {
+	r := rand.New(1)
+	want := r.Peek(0)
+	if err := r.TrySeedState(0, 0, 0); err == nil {
+		t.Errorf("got no error for all-zero state")
+	}
+	if got := r.Uint64(); got != want {
+		t.Errorf("generator changed after rejected state: got %v instead of %v", got, want)
+	}
+	if err := r.TrySeedState(0, 0, 1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRand_NonDegenerateSeeds(t *testing.T) {
+	// seeds mixed by New and Seed never repeat values early; raw low-entropy states
+	// set by SeedState may start with a few repeats, but never with a constant stream
+	check := func(t *testing.T, name string, r *rand.Rand, strict bool) {
+		seen := map[uint64]bool{}
+		for i := 0; i < 100; i++ {
+			u := r.Uint64()
+			if strict && seen[u] {
+				t.Fatalf("%v: got repeated value %v at %v", name, u, i)
+			}
+			seen[u] = true
+		}
+		if len(seen) < 50 {
+			t.Fatalf("%v: got only %v distinct values out of 100", name, len(seen))
+		}
+	}
+	check(t, "Seed(0)", rand.New(0), true)
+	check(t, "New(0, 0)", rand.New(0, 0), true)
+	check(t, "New(0, 0, 0)", rand.New(0, 0, 0), true)
+	for i := uint64(0); i < small; i++ {
+		check(t, fmt.Sprintf("Seed(%v)", i), rand.New(i), true)
+	}
+	for i := 0; i < 64; i++ {
+		r := rand.New()
+		r.SeedState(1<<i, 0, 0)
+		check(t, fmt.Sprintf("SeedState(1<<%v, 0, 0)", i), r, false)
+		r.SeedState(0, 0, 1<<i)
+		check(t, fmt.Sprintf("SeedState(0, 0, 1<<%v)", i), r, false)
+	}
+}
+
+func TestRand_Mix(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		e := rapid.Uint64Range(1, math.MaxUint64).Draw(t, "e").(uint64)
+		r1 := rand.New(s)
+		r2 := rand.New(s)
+		r1.Mix(e)
+		// compare against the unmixed stream, aligned to any of the next few positions
+		var u2 [32]uint64
+		for i := range u2 {
+			u2[i] = r2.Uint64()
+		}
+		for i := 0; i < 8; i++ {
+			u1 := r1.Uint64()
+			for _, u := range u2 {
+				if u1 == u {
+					t.Fatalf("mixed stream value %v matches unmixed stream", u1)
+				}
+			}
+		}
+	})
+}
+
+func TestRand_Mix_Uniform(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	var sum float64
+	for i := 0; i < N; i++ {
+		if i%100 == 0 {
+			r.Mix(uint64(i))
+		}
+		sum += r.Float64()
+	}
+	if m := sum / N; math.Abs(m-0.5) > 5*math.Sqrt(1.0/12/N) {
+		t.Errorf("got mean %v instead of 0.5", m)
+	}
+}
+
+func TestRand_ShuffleKeyed(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s1 := rapid.Uint64().Draw(t, "s1").(uint64)
+		s2 := rapid.Uint64().Draw(t, "s2").(uint64)
+		key := rapid.Uint64().Draw(t, "key").(uint64)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		r1, r2 := rand.New(s1), rand.New(s2)
+		r2.Uint64()
+		want := r1.Peek(0)
+		a, b := make([]int, n), make([]int, n)
+		for i := range a {
+			a[i], b[i] = i, i
+		}
+		r1.ShuffleKeyed(n, key, func(i, j int) { a[i], a[j] = a[j], a[i] })
+		r2.ShuffleKeyed(n, key, func(i, j int) { b[i], b[j] = b[j], b[i] })
+		for i := range a {
+			if a[i] != b[i] {
+				t.Fatalf("got different permutations %v and %v for the same key", a, b)
+			}
+		}
+		if got := r1.Uint64(); got != want {
+			t.Fatalf("generator was advanced by ShuffleKeyed")
+		}
+	})
+}
+
 func TestRand_Uint32nOpt(t *testing.T) {
 	rapid.Check(t, func(t *rapid.T) {
 		n := rapid.Uint32().Draw(t, "n").(uint32)
@@ -157,3 +385,304 @@ func TestRand_Uint32nOpt(t *testing.T) {
 		}
 	})
 }
+
+func TestRand_IntnExcept(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(2, math.MaxInt).Draw(t, "n").(int)
+		e := rapid.IntRange(0, n-1).Draw(t, "e").(int)
+		v := r.IntnExcept(n, e)
+		if v < 0 || v >= n || v == e {
+			t.Fatalf("got %v outside of [0, %v) \\ {%v}", v, n, e)
+		}
+	})
+}
+
+func TestRand_IntnExcept_Uniform(t *testing.T) {
+	const (
+		n = 7
+		N = 70000
+	)
+	r := rand.New(1)
+	for e := 0; e < n; e++ {
+		var counts [n]int
+		for i := 0; i < N; i++ {
+			counts[r.IntnExcept(n, e)]++
+		}
+		if counts[e] != 0 {
+			t.Fatalf("excluded value %v returned %v times", e, counts[e])
+		}
+		want := float64(N) / (n - 1)
+		for v, c := range counts {
+			if v != e && math.Abs(float64(c)-want) > 5*math.Sqrt(want) {
+				t.Errorf("value %v returned %v times instead of ~%v when excluding %v", v, c, want, e)
+			}
+		}
+	}
+}
+
+func TestRand_BufferedBytes(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		k := rapid.IntRange(0, small).Draw(t, "k").(int)
+		_, _ = r.Read(make([]byte, k))
+		if b, want := r.BufferedBytes(), (8-k%8)%8; b != want {
+			t.Fatalf("got %v buffered bytes after reading %v instead of %v", b, k, want)
+		}
+	})
+}
+
+func TestFromStdSource(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Int64().Draw(t, "s").(int64)
+		r1 := rand.FromStdSource(mathrand.NewSource(s))
+		r2 := rand.FromStdSource(mathrand.NewSource(s))
+		for i := 0; i < 10; i++ {
+			if u1, u2 := r1.Uint64(), r2.Uint64(); u1 != u2 {
+				t.Fatalf("got %v and %v from generators with the same std source", u1, u2)
+			}
+		}
+	})
+}
+
+func TestRand_Substreams(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		count := rapid.IntRange(0, 10).Draw(t, "count").(int)
+		s1, s2 := rand.New(s).Substreams(count), rand.New(s).Substreams(count)
+		if len(s1) != count {
+			t.Fatalf("got %v substreams instead of %v", len(s1), count)
+		}
+		for i := range s1 {
+			if u1, u2 := s1[i].Uint64(), s2[i].Uint64(); u1 != u2 {
+				t.Fatalf("substream %v: got %v and %v from the same seed", i, u1, u2)
+			}
+		}
+	})
+}
+
+func TestRand_Substreams_Decorrelated(t *testing.T) {
+	const (
+		N     = 100000
+		count = 4
+	)
+	streams := rand.New(1).Substreams(count)
+	x := make([][]float64, count)
+	for i := range x {
+		x[i] = make([]float64, N)
+		for j := range x[i] {
+			x[i][j] = streams[i].Float64()
+		}
+	}
+	for i := 0; i < count; i++ {
+		for j := i + 1; j < count; j++ {
+			if c := correlation(x[i], x[j]); math.Abs(c) > 5/math.Sqrt(N) {
+				t.Errorf("got correlation %v between substreams %v and %v", c, i, j)
+			}
+		}
+	}
+}
+
+func TestRand_ForKey(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		n := rapid.IntRange(1, 10).Draw(t, "n").(int)
+		keys := make([]uint64, n)
+		for i := range keys {
+			keys[i] = rapid.Uint64().Draw(t, "key").(uint64)
+		}
+		r1, r2 := rand.New(s), rand.New(s)
+		want := map[uint64]uint64{}
+		for _, k := range keys {
+			want[k] = r1.ForKey(k).Uint64()
+		}
+		order := r2.Perm(n)
+		r2.Seed(s)
+		for _, i := range order {
+			k := keys[i]
+			if u := r2.ForKey(k).Uint64(); u != want[k] {
+				t.Fatalf("key %v: got %v instead of %v", k, u, want[k])
+			}
+		}
+		if len(want) > 1 {
+			seen := map[uint64]bool{}
+			for _, u := range want {
+				seen[u] = true
+			}
+			if len(seen) != len(want) {
+				t.Fatalf("different keys produce identical streams")
+			}
+		}
+	})
+}
+
+func TestNewFromString(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		k1 := rapid.String().Draw(t, "k1").(string)
+		k2 := rapid.String().Draw(t, "k2").(string)
+		r1, r2, r3 := rand.NewFromString(k1), rand.NewFromString(k1), rand.NewFromString(k2)
+		diverged := false
+		for i := 0; i < 10; i++ {
+			u1, u2, u3 := r1.Uint64(), r2.Uint64(), r3.Uint64()
+			if u1 != u2 {
+				t.Fatalf("got %v and %v from generators with the same key", u1, u2)
+			}
+			diverged = diverged || u1 != u3
+		}
+		if k1 != k2 && !diverged {
+			t.Fatalf("generators with keys %q and %q produce identical streams", k1, k2)
+		}
+	})
+}
+
+func TestNewFromString_Golden(t *testing.T) {
+	// the seeding must not depend on the process or the machine
+	for _, c := range []struct {
+		key  string
+		want uint64
+	}{
+		{"", 0xa22a4d704e1ec0e},
+		{"pgregory.net/rand", 0x9d8636a20cf32717},
+		{"pgregory.net/rand\x00", 0x279b1bc84f69227b},
+	} {
+		if got := rand.NewFromString(c.key).Uint64(); got != c.want {
+			t.Errorf("got %#x for key %q instead of %#x", got, c.key, c.want)
+		}
+	}
+}
+
+func TestRand_RandomSetBit(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		mask := rapid.Uint64().Draw(t, "mask").(uint64)
+		i := r.RandomSetBit(mask)
+		if mask == 0 {
+			if i != -1 {
+				t.Fatalf("got %v instead of -1 for zero mask", i)
+			}
+		} else if i < 0 || i >= 64 || mask&(1<<i) == 0 {
+			t.Fatalf("got %v which is not a set bit of %#x", i, mask)
+		}
+	})
+}
+
+func TestRand_RandomSetBit_Uniform(t *testing.T) {
+	const (
+		mask = uint64(0x8000_0001_0100_1011)
+		N    = 60000
+	)
+	r := rand.New(1)
+	counts := map[int]int{}
+	for i := 0; i < N; i++ {
+		counts[r.RandomSetBit(mask)]++
+	}
+	want := float64(N) / float64(bits.OnesCount64(mask))
+	for i, c := range counts {
+		if mask&(1<<i) == 0 {
+			t.Fatalf("got %v which is not a set bit of %#x", i, mask)
+		}
+		if math.Abs(float64(c)-want) > 5*math.Sqrt(want) {
+			t.Errorf("bit %v selected %v times instead of ~%v", i, c, want)
+		}
+	}
+}
+
+func TestRand_Peek(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		n := rapid.Uint64Range(0, small).Draw(t, "n").(uint64)
+		r := rand.New(s)
+		p0, pn := r.Peek(0), r.Peek(n)
+		clone := *r
+		for i := uint64(0); i < n; i++ {
+			clone.Uint64()
+		}
+		if v := clone.Uint64(); v != pn {
+			t.Fatalf("Peek(%v) returned %v instead of %v", n, pn, v)
+		}
+		if v := r.Uint64(); v != p0 {
+			t.Fatalf("Peek(0) returned %v instead of %v", p0, v)
+		}
+	})
+}
+
+func TestRand_Position(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		n := rapid.Uint64Range(0, small).Draw(t, "n").(uint64)
+		r := rand.New(s)
+		p := r.Position()
+		for i := uint64(0); i < n; i++ {
+			r.Uint64()
+		}
+		if d := r.Position() - p; d != n {
+			t.Fatalf("position advanced by %v instead of %v", d, n)
+		}
+	})
+}
+
+func TestRand_ReadByte(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		const N = 32
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		buf := make([]byte, N)
+		_, _ = r.Read(buf)
+		r.Seed(s)
+		var _ io.ByteScanner = r
+		for n := 0; n < N; {
+			if rapid.Bool().Draw(t, "byte").(bool) {
+				b, _ := r.ReadByte()
+				if b != buf[n] {
+					t.Fatalf("got byte %v instead of %v at %v", b, buf[n], n)
+				}
+				n++
+				if rapid.Bool().Draw(t, "unread").(bool) {
+					if err := r.UnreadByte(); err != nil {
+						t.Fatalf("got unexpected unread error: %v", err)
+					}
+					if err := r.UnreadByte(); err == nil {
+						t.Fatalf("no error on second unread")
+					}
+					n--
+				}
+			} else {
+				c := rapid.IntRange(0, N-n).Draw(t, "c").(int)
+				got := make([]byte, c)
+				_, _ = r.Read(got)
+				if !bytes.Equal(got, buf[n:n+c]) {
+					t.Fatalf("got bytes %v instead of %v at %v", got, buf[n:n+c], n)
+				}
+				n += c
+				if c > 0 && r.UnreadByte() == nil {
+					t.Fatalf("no error on unread after Read")
+				}
+			}
+		}
+	})
+}
+
+func TestRand_UnreadByte(t *testing.T) {
+	r := rand.New(1)
+	if err := r.UnreadByte(); err == nil {
+		t.Fatalf("no error on unread before any read")
+	}
+	for i := 0; i < 20; i++ {
+		b1, _ := r.ReadByte()
+		if err := r.UnreadByte(); err != nil {
+			t.Fatalf("got unexpected unread error: %v", err)
+		}
+		b2, _ := r.ReadByte()
+		if b1 != b2 {
+			t.Fatalf("got %v after unreading %v", b2, b1)
+		}
+	}
+	r.ReadByte()
+	r.Uint32()
+	if err := r.UnreadByte(); err == nil {
+		t.Fatalf("no error on unread after Uint32")
+	}
+}
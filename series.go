@@ -0,0 +1,80 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "math"
+
+// RandomWalk returns the positions of a random walk starting at 0 after each of n steps,
+// where the steps are normally distributed with mean 0 and standard deviation stepStddev.
+// It panics if n < 0 or stepStddev < 0.
+func (r *Rand) RandomWalk(n int, stepStddev float64) []float64 {
+	if n < 0 {
+		panic("invalid argument to RandomWalk")
+	}
+	s := make([]float64, n)
+	r.RandomWalkInto(s, stepStddev)
+	return s
+}
+
+// RandomWalkInto is like [Rand.RandomWalk], but writes len(dst) positions into dst
+// instead of allocating a new slice. It panics if stepStddev < 0.
+func (r *Rand) RandomWalkInto(dst []float64, stepStddev float64) {
+	if !(stepStddev >= 0) {
+		panic("invalid argument to RandomWalkInto")
+	}
+	x := 0.0
+	for i := range dst {
+		x += r.NormFloat64() * stepStddev
+		dst[i] = x
+	}
+}
+
+// AR1 returns n values of a stationary first-order autoregressive process,
+// x[t] = phi*x[t-1] + eps[t], where the innovations eps[t] are normally distributed with
+// mean 0 and standard deviation stddev. x[0] is drawn from the stationary distribution,
+// so the series has no burn-in period. It panics if n < 0, |phi| >= 1 or stddev < 0.
+func (r *Rand) AR1(n int, phi, stddev float64) []float64 {
+	if n < 0 || !(math.Abs(phi) < 1) || !(stddev >= 0) {
+		panic("invalid argument to AR1")
+	}
+	s := make([]float64, n)
+	if n == 0 {
+		return s
+	}
+	s[0] = r.NormFloat64() * stddev / math.Sqrt(1-phi*phi)
+	for i := 1; i < n; i++ {
+		s[i] = phi*s[i-1] + r.NormFloat64()*stddev
+	}
+	return s
+}
+
+// StepFunction returns a pseudo-random non-decreasing step function with n steps: the function
+// takes the value ys[i] on [xs[i], xs[i+1]). The breakpoints xs are strictly increasing in the
+// half-open interval [0, domain), and the values ys are non-decreasing in the closed interval [0, rangeMax].
+// It panics if n < 0, domain <= 0, rangeMax < 0, or n exceeds the number of float64 values in [0, domain).
+func (r *Rand) StepFunction(n int, domain, rangeMax float64) (xs, ys []float64) {
+	if n < 0 || !(domain > 0) || math.IsInf(domain, 1) || !(rangeMax >= 0) || math.IsInf(rangeMax, 1) {
+		panic("invalid argument to StepFunction")
+	}
+	xs = r.SortedFloat64s(n)
+	prev := math.Inf(-1)
+	for i := range xs {
+		xs[i] *= domain
+		if xs[i] <= prev { // break ties caused by rounding
+			xs[i] = math.Nextafter(prev, domain)
+		}
+		if xs[i] >= domain {
+			panic("invalid argument to StepFunction")
+		}
+		prev = xs[i]
+	}
+	ys = r.SortedFloat64s(n)
+	for i := range ys {
+		ys[i] *= rangeMax
+	}
+	return xs, ys
+}
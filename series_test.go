@@ -0,0 +1,93 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+func TestRand_RandomWalk(t *testing.T) {
+	const (
+		N     = 20000
+		sigma = 1.5
+	)
+	r := rand.New(1)
+	for _, n := range []int{1, 10, 100} {
+		final := make([]float64, N)
+		for i := range final {
+			w := r.RandomWalk(n, sigma)
+			if len(w) != n {
+				t.Fatalf("got %v positions instead of %v", len(w), n)
+			}
+			final[i] = w[n-1]
+		}
+		_, v := meanVariance(final)
+		want := float64(n) * sigma * sigma
+		if math.Abs(v-want) > 0.05*want {
+			t.Errorf("n %v: got final position variance %v instead of %v", n, v, want)
+		}
+	}
+}
+
+func TestRand_RandomWalkInto(t *testing.T) {
+	r1, r2 := rand.New(1), rand.New(1)
+	w := r1.RandomWalk(100, 2)
+	dst := make([]float64, 100)
+	if a := testing.AllocsPerRun(10, func() { r1.RandomWalkInto(dst, 2) }); a != 0 {
+		t.Errorf("got %v allocations", a)
+	}
+	r2.RandomWalkInto(dst, 2)
+	for i := range w {
+		if w[i] != dst[i] {
+			t.Fatalf("got %v instead of %v at %v", dst[i], w[i], i)
+		}
+	}
+}
+
+func TestRand_AR1(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	for _, phi := range []float64{-0.9, -0.3, 0, 0.5, 0.95} {
+		x := r.AR1(N, phi, 2)
+		m, v := meanVariance(x)
+		c := 0.0
+		for i := 1; i < N; i++ {
+			c += (x[i] - m) * (x[i-1] - m)
+		}
+		if rho := c / float64(N-1) / v; math.Abs(rho-phi) > 0.02 {
+			t.Errorf("phi %v: got lag-1 autocorrelation %v", phi, rho)
+		}
+		if wantV := 4 / (1 - phi*phi); math.Abs(v-wantV) > 0.1*wantV {
+			t.Errorf("phi %v: got variance %v instead of %v", phi, v, wantV)
+		}
+	}
+}
+
+func TestRand_StepFunction(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		domain := rapid.Float64Range(1e-300, 1e300).Draw(t, "domain").(float64)
+		rangeMax := rapid.Float64Range(0, 1e300).Draw(t, "rangeMax").(float64)
+		xs, ys := r.StepFunction(n, domain, rangeMax)
+		if len(xs) != n || len(ys) != n {
+			t.Fatalf("got %v breakpoints and %v values instead of %v", len(xs), len(ys), n)
+		}
+		for i := range xs {
+			if xs[i] < 0 || xs[i] >= domain || (i > 0 && xs[i] <= xs[i-1]) {
+				t.Fatalf("breakpoints %v are not strictly increasing in [0, %v)", xs, domain)
+			}
+			if ys[i] < 0 || ys[i] > rangeMax || (i > 0 && ys[i] < ys[i-1]) {
+				t.Fatalf("values %v are not non-decreasing in [0, %v]", ys, rangeMax)
+			}
+		}
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "math/bits"
+
+const (
+	sobolBits    = 32
+	sobolMaxDims = len(sobolParams) + 1
+	sobolMul     = 0x1.0p-32
+)
+
+// sobolParams are the primitive polynomial degrees s, coefficients a and initial
+// direction numbers m for dimensions 2 and up, from "Constructing Sobol sequences
+// with better two-dimensional projections" by Stephen Joe and Frances Y. Kuo.
+var sobolParams = [...]struct {
+	s uint
+	a uint32
+	m []uint32
+}{
+	{1, 0, []uint32{1}},
+	{2, 1, []uint32{1, 3}},
+	{3, 1, []uint32{1, 3, 1}},
+	{3, 2, []uint32{1, 1, 1}},
+	{4, 1, []uint32{1, 1, 3, 3}},
+	{4, 4, []uint32{1, 3, 5, 13}},
+	{5, 2, []uint32{1, 1, 5, 5, 17}},
+	{5, 4, []uint32{1, 1, 5, 5, 5}},
+	{5, 7, []uint32{1, 1, 7, 11, 19}},
+	{5, 11, []uint32{1, 1, 5, 1, 1}},
+	{5, 13, []uint32{1, 1, 1, 3, 11}},
+	{5, 14, []uint32{1, 3, 5, 5, 31}},
+	{6, 1, []uint32{1, 3, 3, 9, 7, 49}},
+	{6, 13, []uint32{1, 1, 1, 15, 21, 21}},
+	{6, 16, []uint32{1, 3, 1, 13, 27, 49}},
+}
+
+// A Sobol generates the Sobol low-discrepancy sequence of points in [0, 1)^dims,
+// for use in quasi-Monte Carlo methods. Unlike pseudo-random points, the points
+// of the sequence are deterministic and cover the unit hypercube evenly.
+type Sobol struct {
+	v [][sobolBits]uint32
+	x []uint32
+	n uint64
+}
+
+// NewSobol returns a Sobol generator of dims-dimensional points.
+// It panics if dims < 1 or dims > 16.
+func NewSobol(dims int) *Sobol {
+	if dims < 1 || dims > sobolMaxDims {
+		panic("invalid argument to NewSobol")
+	}
+	s := &Sobol{
+		v: make([][sobolBits]uint32, dims),
+		x: make([]uint32, dims),
+	}
+	for i := 0; i < sobolBits; i++ {
+		s.v[0][i] = 1 << (sobolBits - 1 - i)
+	}
+	for d := 1; d < dims; d++ {
+		p := sobolParams[d-1]
+		v := &s.v[d]
+		for i := uint(0); i < sobolBits; i++ {
+			if i < p.s {
+				v[i] = p.m[i] << (sobolBits - 1 - i)
+				continue
+			}
+			v[i] = v[i-p.s] ^ v[i-p.s]>>p.s
+			for k := uint(1); k < p.s; k++ {
+				if (p.a>>(p.s-1-k))&1 != 0 {
+					v[i] ^= v[i-k]
+				}
+			}
+		}
+	}
+	return s
+}
+
+// Next returns the next point of the sequence, starting with the origin.
+// It panics after 2^32 points have been generated.
+func (s *Sobol) Next() []float64 {
+	p := make([]float64, len(s.x))
+	s.NextInto(p)
+	return p
+}
+
+// NextInto is like [Sobol.Next], but writes the point into dst, which must have dims elements.
+// It panics after 2^32 points have been generated.
+func (s *Sobol) NextInto(dst []float64) {
+	if s.n == 1<<sobolBits {
+		panic("Sobol sequence exhausted")
+	}
+	for d, x := range s.x {
+		dst[d] = float64(x) * sobolMul
+	}
+	// Gray code order: flip the direction number of the lowest zero bit of n;
+	// that bit is out of range only for the last point, which has no successor
+	if c := bits.TrailingZeros64(^s.n); c < sobolBits {
+		for d := range s.x {
+			s.x[d] ^= s.v[d][c]
+		}
+	}
+	s.n++
+}
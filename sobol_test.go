@@ -0,0 +1,106 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"fmt"
+	"math"
+	"pgregory.net/rand"
+	"testing"
+)
+
+// discrepancy estimates the star discrepancy of points using random anchored boxes.
+func discrepancy(r *rand.Rand, points [][]float64) float64 {
+	d := 0.0
+	dims := len(points[0])
+	a := make([]float64, dims)
+	for k := 0; k < 2000; k++ {
+		vol := 1.0
+		for i := range a {
+			a[i] = r.Float64()
+			vol *= a[i]
+		}
+		n := 0
+	outer:
+		for _, p := range points {
+			for i := range p {
+				if p[i] >= a[i] {
+					continue outer
+				}
+			}
+			n++
+		}
+		d = math.Max(d, math.Abs(float64(n)/float64(len(points))-vol))
+	}
+	return d
+}
+
+func TestSobol(t *testing.T) {
+	const N = 1024
+	r := rand.New(1)
+	for _, dims := range []int{1, 2, 3, 5} {
+		s := rand.NewSobol(dims)
+		sobol := make([][]float64, N)
+		uniform := make([][]float64, N)
+		for i := range sobol {
+			sobol[i] = s.Next()
+			uniform[i] = make([]float64, dims)
+			for j := range sobol[i] {
+				if sobol[i][j] < 0 || sobol[i][j] >= 1 {
+					t.Fatalf("got %v outside of [0, 1)", sobol[i])
+				}
+				uniform[i][j] = r.Float64()
+			}
+		}
+		ds, du := discrepancy(r, sobol), discrepancy(r, uniform)
+		if ds > du/2 {
+			t.Errorf("dims %v: Sobol discrepancy %v is not much lower than uniform discrepancy %v", dims, ds, du)
+		}
+	}
+}
+
+func TestSobol_Stratified(t *testing.T) {
+	// the first 2^k points of every dimension contain exactly one point in each interval [i/2^k, (i+1)/2^k)
+	const k = 8
+	s := rand.NewSobol(16)
+	var seen [16][1 << k]bool
+	for i := 0; i < 1<<k; i++ {
+		for d, x := range s.Next() {
+			j := int(x * (1 << k))
+			if seen[d][j] {
+				t.Fatalf("dimension %v has two points in interval %v", d, j)
+			}
+			seen[d][j] = true
+		}
+	}
+}
+
+func TestSobol_Exhausted(t *testing.T) {
+	s, want := rand.NewSobol(3), rand.NewSobol(3)
+	rand.SetSobolIndexForTest(s, 100)
+	for i := 0; i < 100; i++ {
+		want.Next()
+	}
+	if p, q := s.Next(), want.Next(); fmt.Sprint(p) != fmt.Sprint(q) {
+		t.Fatalf("got point %v instead of %v after skipping", p, q)
+	}
+	// the last two of the 2^32 points can be generated
+	rand.SetSobolIndexForTest(s, 1<<32-2)
+	for i := 0; i < 2; i++ {
+		for _, x := range s.Next() {
+			if x < 0 || x >= 1 {
+				t.Fatalf("got %v outside of [0, 1)", x)
+			}
+		}
+	}
+	defer func() {
+		if recover() != "Sobol sequence exhausted" {
+			t.Errorf("no exhaustion panic after 2^32 points")
+		}
+	}()
+	s.Next()
+}
@@ -24,6 +24,29 @@ var (
 	skipregress = flag.Bool("skipregress", false, "skip the regression test")
 )
 
+// regressMethods are the methods covered by the golden outputs.
+// Methods added later are left out so that they do not shift the shared stream.
+var regressMethods = map[string]bool{
+	"ExpFloat64":    true,
+	"Float32":       true,
+	"Float64":       true,
+	"Int":           true,
+	"Int31":         true,
+	"Int31n":        true,
+	"Int63":         true,
+	"Int63n":        true,
+	"Intn":          true,
+	"MarshalBinary": true,
+	"NormFloat64":   true,
+	"Perm":          true,
+	"Read":          true,
+	"Shuffle":       true,
+	"Uint32":        true,
+	"Uint32n":       true,
+	"Uint64":        true,
+	"Uint64n":       true,
+}
+
 func TestRegress(t *testing.T) {
 	if *skipregress {
 		t.Skip("-skipregress specified")
@@ -48,7 +71,7 @@ func TestRegress(t *testing.T) {
 		m := rv.Type().Method(i)
 		mv := rv.Method(i)
 		mt := mv.Type()
-		if m.Name == "Get" || m.Name == "Seed" || m.Name == "UnmarshalBinary" {
+		if !regressMethods[m.Name] {
 			continue
 		}
 		for repeat := 0; repeat < 17; repeat++ {
@@ -0,0 +1,157 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+var errInvalidWeights = errors.New("rand: weights must be finite, non-negative and not all zero")
+
+// cumulativeWeights returns the running sums of weights.
+func cumulativeWeights(weights []float64) ([]float64, error) {
+	cum := make([]float64, len(weights))
+	sum := 0.0
+	for i, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			return nil, errInvalidWeights
+		}
+		sum += w
+		cum[i] = sum
+	}
+	if !(sum > 0) || math.IsInf(sum, 1) {
+		return nil, errInvalidWeights
+	}
+	return cum, nil
+}
+
+// searchCumulative returns an index i chosen with probability proportional to cum[i] - cum[i-1].
+func (r *Rand) searchCumulative(cum []float64) int {
+	u := r.Float64() * cum[len(cum)-1]
+	i := sort.Search(len(cum), func(i int) bool { return cum[i] > u })
+	if i == len(cum) { // guard against rounding
+		i = len(cum) - 1
+	}
+	return i
+}
+
+// SampleLogits returns an index i chosen with probability proportional to exp(logits[i] / temperature),
+// i.e. sampled from the softmax of logits at the given temperature. As temperature approaches 0,
+// the distribution approaches the argmax of logits, which is returned when temperature is 0.
+// It panics if logits is empty or temperature < 0.
+func (r *Rand) SampleLogits(logits []float64, temperature float64) int {
+	if len(logits) == 0 || !(temperature >= 0) {
+		panic("invalid argument to SampleLogits")
+	}
+	m := 0
+	for i, l := range logits {
+		if l > logits[m] {
+			m = i
+		}
+	}
+	if temperature == 0 {
+		return m
+	}
+	// subtracting the maximum keeps the exponents in (-Inf, 0]
+	max := logits[m]
+	sum := 0.0
+	for _, l := range logits {
+		sum += math.Exp((l - max) / temperature)
+	}
+	u := r.Float64() * sum
+	for i, l := range logits {
+		u -= math.Exp((l - max) / temperature)
+		if u < 0 {
+			return i
+		}
+	}
+	return m // rounding errors
+}
+
+// Lottery returns, as a slice of ints, winners distinct indices into weights. Winners are drawn
+// one at a time, each index being chosen with probability proportional to its weight among
+// the indices not chosen yet. It panics if weights are negative or infinite, or if winners
+// is negative or exceeds the number of positive weights.
+func (r *Rand) Lottery(weights []float64, winners int) []int {
+	positive := 0
+	for _, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			panic("invalid argument to Lottery")
+		}
+		if w > 0 {
+			positive++
+		}
+	}
+	if winners < 0 || winners > positive {
+		panic("invalid argument to Lottery")
+	}
+	w := append([]float64(nil), weights...)
+	s := make([]int, winners)
+	for k := range s {
+		sum := 0.0
+		last := -1
+		for i, v := range w {
+			sum += v
+			if v > 0 {
+				last = i
+			}
+		}
+		u := r.Float64() * sum
+		j := last // fallback in case of rounding errors
+		for i, v := range w {
+			u -= v
+			if v > 0 && u < 0 {
+				j = i
+				break
+			}
+		}
+		s[k] = j
+		w[j] = 0
+	}
+	return s
+}
+
+// PickBranch returns an index i chosen with probability proportional to weights[i].
+// The common case of two branches avoids any loops. It panics if no weights are given,
+// or if they are negative, infinite or all zero.
+func (r *Rand) PickBranch(weights ...float64) int {
+	if len(weights) == 2 {
+		w0, w1 := weights[0], weights[1]
+		sum := w0 + w1
+		if !(w0 >= 0 && w1 >= 0 && sum > 0) || math.IsInf(sum, 1) {
+			panic("invalid argument to PickBranch")
+		}
+		if r.Float64()*sum < w0 {
+			return 0
+		}
+		return 1
+	}
+	sum := 0.0
+	for _, w := range weights {
+		if !(w >= 0) {
+			panic("invalid argument to PickBranch")
+		}
+		sum += w
+	}
+	if !(sum > 0) || math.IsInf(sum, 1) {
+		panic("invalid argument to PickBranch")
+	}
+	u := r.Float64() * sum
+	last := 0
+	for i, w := range weights {
+		if w > 0 {
+			if u < w {
+				return i
+			}
+			u -= w
+			last = i
+		}
+	}
+	return last // rounding errors
+}
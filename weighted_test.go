@@ -0,0 +1,125 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+func TestRand_SampleLogits(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	logits := []float64{1, 3, 0.5, 2}
+	for _, temp := range []float64{0.5, 1, 3} {
+		counts := make([]float64, len(logits))
+		for i := 0; i < N; i++ {
+			counts[r.SampleLogits(logits, temp)]++
+		}
+		sum := 0.0
+		for _, l := range logits {
+			sum += math.Exp(l / temp)
+		}
+		for i, l := range logits {
+			p := math.Exp(l/temp) / sum
+			if math.Abs(counts[i]/N-p) > 5*math.Sqrt(p*(1-p)/N) {
+				t.Errorf("temperature %v: index %v sampled with frequency %v instead of %v", temp, i, counts[i]/N, p)
+			}
+		}
+		if counts[1] <= counts[3] || counts[3] <= counts[0] || counts[0] <= counts[2] {
+			t.Errorf("temperature %v: higher logits do not dominate: %v", temp, counts)
+		}
+	}
+	for _, temp := range []float64{0, 1e-300, 1e-3} {
+		for i := 0; i < 1000; i++ {
+			if k := r.SampleLogits(logits, temp); k != 1 {
+				t.Fatalf("temperature %v: got %v instead of argmax 1", temp, k)
+			}
+		}
+	}
+}
+
+func TestRand_Lottery(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.IntRange(0, 100).Draw(t, "n").(int)
+		weights := make([]float64, n)
+		positive := 0
+		for i := range weights {
+			if rapid.Bool().Draw(t, "positive").(bool) {
+				weights[i] = rapid.Float64Range(0.001, 1000).Draw(t, "w").(float64)
+				positive++
+			}
+		}
+		winners := rapid.IntRange(0, positive).Draw(t, "winners").(int)
+		seen := map[int]bool{}
+		for _, i := range r.Lottery(weights, winners) {
+			if seen[i] || !(weights[i] > 0) {
+				t.Fatalf("got repeated or zero-weight winner %v", i)
+			}
+			seen[i] = true
+		}
+		if len(seen) != winners {
+			t.Fatalf("got %v winners instead of %v", len(seen), winners)
+		}
+	})
+}
+
+func TestRand_Lottery_Weights(t *testing.T) {
+	const N = 100000
+	weights := []float64{1, 2, 0, 3, 10}
+	r := rand.New(1)
+	var first, won [5]int
+	for i := 0; i < N; i++ {
+		w := r.Lottery(weights, 2)
+		first[w[0]]++
+		won[w[0]]++
+		won[w[1]]++
+	}
+	for i, c := range first {
+		want := N * weights[i] / 16
+		if math.Abs(float64(c)-want) > 5*math.Sqrt(want)+1 {
+			t.Errorf("index %v won first %v times instead of ~%v", i, c, want)
+		}
+	}
+	for i := 1; i < len(won); i++ {
+		if weights[i] > weights[i-1] && won[i] <= won[i-1] {
+			t.Errorf("index %v with weight %v won less often than index %v with weight %v: %v", i, weights[i], i-1, weights[i-1], won)
+		}
+	}
+}
+
+func TestRand_PickBranch(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	for _, weights := range [][]float64{
+		{1},
+		{1, 3},
+		{0, 2},
+		{0.5, 0.5},
+		{1, 0, 2, 3, 4},
+	} {
+		sum := 0.0
+		for _, w := range weights {
+			sum += w
+		}
+		counts := make([]int, len(weights))
+		for i := 0; i < N; i++ {
+			counts[r.PickBranch(weights...)]++
+		}
+		for i, c := range counts {
+			p := weights[i] / sum
+			want := N * p
+			if math.Abs(float64(c)-want) > 5*math.Sqrt(N*p*(1-p)) {
+				t.Errorf("weights %v: branch %v taken %v times instead of ~%v", weights, i, c, want)
+			}
+		}
+	}
+}